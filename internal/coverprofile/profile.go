@@ -0,0 +1,183 @@
+package coverprofile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Block 覆盖率文件中的一个语句块
+type Block struct {
+	File      string `json:"file"` // 导入路径形式的文件名，如 example.com/app/parse/parse.go
+	StartLine int    `json:"start_line"`
+	StartCol  int    `json:"start_col"`
+	EndLine   int    `json:"end_line"`
+	EndCol    int    `json:"end_col"`
+	NumStmt   int    `json:"num_stmt"`
+	Count     int64  `json:"count"`
+}
+
+// Profile go test -coverprofile 生成的覆盖率文件，多个包的输出拼接在一起时会重复出现 mode 行
+type Profile struct {
+	Mode   string  `json:"mode"` // set、count 或 atomic
+	Blocks []Block `json:"blocks"`
+}
+
+// example.com/app/parse/parse.go:10.34,12.2 1 1
+var blockPattern = regexp.MustCompile(`^(.+):(\d+)\.(\d+),(\d+)\.(\d+) (\d+) (\d+)$`)
+
+// Parse 解析覆盖率文件
+func Parse(reader io.Reader) (*Profile, error) {
+	profile := &Profile{
+		Blocks: make([]Block, 0),
+	}
+	
+	scanner := bufio.NewScanner(reader)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		
+		if strings.HasPrefix(line, "mode: ") {
+			mode := strings.TrimPrefix(line, "mode: ")
+			if mode != "set" && mode != "count" && mode != "atomic" {
+				return nil, fmt.Errorf("line %d: unknown coverage mode %s", lineNum, mode)
+			}
+			if profile.Mode != "" && profile.Mode != mode {
+				return nil, fmt.Errorf("line %d: mode %s conflicts with %s", lineNum, mode, profile.Mode)
+			}
+			profile.Mode = mode
+			continue
+		}
+		
+		matches := blockPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, fmt.Errorf("line %d: invalid coverage block: %s", lineNum, line)
+		}
+		block := Block{File: matches[1]}
+		block.StartLine, _ = strconv.Atoi(matches[2])
+		block.StartCol, _ = strconv.Atoi(matches[3])
+		block.EndLine, _ = strconv.Atoi(matches[4])
+		block.EndCol, _ = strconv.Atoi(matches[5])
+		block.NumStmt, _ = strconv.Atoi(matches[6])
+		block.Count, _ = strconv.ParseInt(matches[7], 10, 64)
+		profile.Blocks = append(profile.Blocks, block)
+	}
+	
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading coverage profile: %w", err)
+	}
+	if profile.Mode == "" {
+		return nil, fmt.Errorf("missing mode line in coverage profile")
+	}
+	
+	return profile, nil
+}
+
+// ParseFile 打开并解析覆盖率文件
+func ParseFile(path string) (*Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open coverage profile: %w", err)
+	}
+	defer file.Close()
+	
+	return Parse(file)
+}
+
+// blockKey 语句块的位置标识
+type blockKey struct {
+	file                                 string
+	startLine, startCol, endLine, endCol int
+}
+
+// Merge 合并多个覆盖率文件（例如分别运行的各个包），模式必须一致。相同位置的语句块在 set 模式下
+// 任意一次执行即视为覆盖，count/atomic 模式下执行次数相加
+func Merge(profiles ...*Profile) (*Profile, error) {
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no coverage profiles to merge")
+	}
+	
+	merged := &Profile{Mode: profiles[0].Mode}
+	blocks := make([]Block, 0)
+	for _, profile := range profiles {
+		if profile.Mode != merged.Mode {
+			return nil, fmt.Errorf("cannot merge coverage profiles with modes %s and %s", merged.Mode, profile.Mode)
+		}
+		blocks = append(blocks, profile.Blocks...)
+	}
+	merged.Blocks = mergeBlocks(merged.Mode, blocks)
+	return merged, nil
+}
+
+// mergeBlocks 合并相同位置的语句块，并按文件和起始位置排序。
+// -coverpkg 时同一语句块也会在多个测试二进制的输出中重复出现
+func mergeBlocks(mode string, blocks []Block) []Block {
+	index := make(map[blockKey]int)
+	merged := make([]Block, 0, len(blocks))
+	for _, block := range blocks {
+		key := blockKey{block.File, block.StartLine, block.StartCol, block.EndLine, block.EndCol}
+		i, exists := index[key]
+		if !exists {
+			index[key] = len(merged)
+			merged = append(merged, block)
+			continue
+		}
+		if mode == "set" {
+			if block.Count > 0 {
+				merged[i].Count = 1
+			}
+		} else {
+			merged[i].Count += block.Count
+		}
+	}
+	
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return a.StartCol < b.StartCol
+	})
+	return merged
+}
+
+// Statements 返回按语句数加权的覆盖情况，重复的语句块只计一次
+func (p *Profile) Statements() (covered, total int) {
+	return countStatements(mergeBlocks(p.Mode, p.Blocks))
+}
+
+// countStatements 统计语句块中已覆盖和全部的语句数
+func countStatements(blocks []Block) (covered, total int) {
+	for _, block := range blocks {
+		total += block.NumStmt
+		if block.Count > 0 {
+			covered += block.NumStmt
+		}
+	}
+	return covered, total
+}
+
+// Percent 返回按语句数加权的总覆盖率百分比，没有语句时返回 0
+func (p *Profile) Percent() float64 {
+	return percent(p.Statements())
+}
+
+// percent 计算覆盖率百分比，没有语句时返回 0
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) / float64(total) * 100
+}
@@ -0,0 +1,117 @@
+package coverprofile
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleProfile = `mode: set
+example.com/app/parse/parse.go:10.34,12.2 2 1
+example.com/app/parse/parse.go:14.2,16.3 3 0
+example.com/app/store/store.go:5.20,8.2 5 1
+`
+
+// TestParse 测试解析覆盖率文件的模式和语句块
+func TestParse(t *testing.T) {
+	// Act
+	profile, err := Parse(strings.NewReader(sampleProfile))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if profile.Mode != "set" || len(profile.Blocks) != 3 {
+		t.Fatalf("Unexpected profile: %+v", profile)
+	}
+	block := profile.Blocks[1]
+	if block.File != "example.com/app/parse/parse.go" || block.StartLine != 14 || block.StartCol != 2 || block.EndLine != 16 || block.EndCol != 3 || block.NumStmt != 3 || block.Count != 0 {
+		t.Errorf("Unexpected block: %+v", block)
+	}
+}
+
+// TestParse_InvalidLine 测试无效行返回错误
+func TestParse_InvalidLine(t *testing.T) {
+	// Act
+	_, err := Parse(strings.NewReader("mode: set\nnot a block\n"))
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for invalid block line")
+	}
+}
+
+// TestProfile_Percent 测试按语句数加权的总覆盖率，重复的语句块只计一次
+func TestProfile_Percent(t *testing.T) {
+	// Arrange
+	profile, err := Parse(strings.NewReader(sampleProfile + "example.com/app/parse/parse.go:14.2,16.3 3 1\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	covered, total := profile.Statements()
+
+	// Assert
+	if covered != 10 || total != 10 {
+		t.Errorf("Expected 10/10 statements, got %d/%d", covered, total)
+	}
+	if profile.Percent() != 100 {
+		t.Errorf("Expected 100%%, got %v", profile.Percent())
+	}
+}
+
+// TestParse_UnknownMode 测试未知模式返回错误
+func TestParse_UnknownMode(t *testing.T) {
+	// Act
+	_, err := Parse(strings.NewReader("mode: sometimes\n"))
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+// TestMerge 测试合并 count 模式的覆盖率文件时执行次数相加
+func TestMerge(t *testing.T) {
+	// Arrange
+	first, err := Parse(strings.NewReader("mode: count\nexample.com/app/a.go:1.1,2.2 1 3\nexample.com/app/a.go:3.1,4.2 1 0\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, err := Parse(strings.NewReader("mode: count\nexample.com/app/a.go:1.1,2.2 1 2\nexample.com/app/b.go:1.1,2.2 2 1\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	merged, err := Merge(first, second)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(merged.Blocks) != 3 {
+		t.Fatalf("Expected 3 blocks, got %+v", merged.Blocks)
+	}
+	if merged.Blocks[0].Count != 5 {
+		t.Errorf("Expected counts to be summed to 5, got %d", merged.Blocks[0].Count)
+	}
+	if covered, total := merged.Statements(); covered != 3 || total != 4 {
+		t.Errorf("Expected 3/4 statements, got %d/%d", covered, total)
+	}
+}
+
+// TestMerge_ModeMismatch 测试模式不一致时不能合并
+func TestMerge_ModeMismatch(t *testing.T) {
+	// Arrange
+	set := &Profile{Mode: "set"}
+	atomic := &Profile{Mode: "atomic"}
+
+	// Act
+	_, err := Merge(set, atomic)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for mismatched modes")
+	}
+}
@@ -0,0 +1,238 @@
+package coverprofile
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/allanpk716/go_test_reader/internal/gomod"
+)
+
+// LineRange 闭区间行范围
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// FileCoverage 单个文件的覆盖率
+type FileCoverage struct {
+	File      string      `json:"file"`
+	Covered   int         `json:"covered"`
+	Total     int         `json:"total"`
+	Percent   float64     `json:"percent"`
+	Uncovered []LineRange `json:"uncovered"`
+}
+
+// FuncCoverage 单个函数的覆盖率，与 go tool cover -func 的统计方式一致
+type FuncCoverage struct {
+	File      string      `json:"file"`
+	Name      string      `json:"name"` // 方法为 "Recv.Name" 或 "(*Recv).Name"
+	StartLine int         `json:"start_line"`
+	EndLine   int         `json:"end_line"`
+	Covered   int         `json:"covered"`
+	Total     int         `json:"total"`
+	Percent   float64     `json:"percent"`
+	Uncovered []LineRange `json:"uncovered"`
+}
+
+// SourceError 无法读取或解析源码、因而没有函数覆盖率的文件
+type SourceError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// MatchFile 判断覆盖率文件中的文件名是否匹配查询，查询可以是完整导入路径、路径后缀或文件名
+func MatchFile(file, query string) bool {
+	query = filepath.ToSlash(query)
+	return file == query || strings.HasSuffix(file, "/"+strings.TrimPrefix(query, "/"))
+}
+
+// MatchFunc 判断函数名是否匹配查询，"Parse" 同时匹配函数 Parse 和任意类型的 Parse 方法
+func MatchFunc(name, query string) bool {
+	return name == query || strings.HasSuffix(name, "."+query)
+}
+
+// Files 按文件汇总覆盖率，包含未覆盖的行范围
+func (p *Profile) Files() []FileCoverage {
+	files := make([]FileCoverage, 0)
+	for _, group := range groupByFile(mergeBlocks(p.Mode, p.Blocks)) {
+		covered, total := countStatements(group)
+		files = append(files, FileCoverage{
+			File:      group[0].File,
+			Covered:   covered,
+			Total:     total,
+			Percent:   percent(covered, total),
+			Uncovered: uncoveredRanges(group),
+		})
+	}
+	return files
+}
+
+// Functions 解析模块根目录下的源文件，按函数汇总覆盖率。
+// 覆盖率文件中的导入路径根据 go.mod 的模块路径映射到磁盘文件，
+// 源码缺失或无法解析的文件不影响其他文件，记录在第二个返回值中
+func (p *Profile) Functions(moduleRoot string) ([]FuncCoverage, []SourceError, error) {
+	modulePath, err := gomod.ModulePath(moduleRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	functions := make([]FuncCoverage, 0)
+	sourceErrors := make([]SourceError, 0)
+	for _, group := range groupByFile(mergeBlocks(p.Mode, p.Blocks)) {
+		file := group[0].File
+		path, ok := sourcePath(moduleRoot, modulePath, file)
+		if !ok {
+			// 依赖模块或其他模块的文件无法在模块根目录下找到源码
+			continue
+		}
+		extents, err := findFuncs(path)
+		if err != nil {
+			sourceErrors = append(sourceErrors, SourceError{File: file, Error: err.Error()})
+			continue
+		}
+		
+		for _, extent := range extents {
+			blocks := blocksInExtent(group, extent)
+			covered, total := countStatements(blocks)
+			functions = append(functions, FuncCoverage{
+				File:      file,
+				Name:      extent.name,
+				StartLine: extent.startLine,
+				EndLine:   extent.endLine,
+				Covered:   covered,
+				Total:     total,
+				Percent:   percent(covered, total),
+				Uncovered: uncoveredRanges(blocks),
+			})
+		}
+	}
+	return functions, sourceErrors, nil
+}
+
+// groupByFile 将已按文件排序的语句块分组
+func groupByFile(blocks []Block) [][]Block {
+	groups := make([][]Block, 0)
+	for i := 0; i < len(blocks); {
+		j := i
+		for j < len(blocks) && blocks[j].File == blocks[i].File {
+			j++
+		}
+		groups = append(groups, blocks[i:j])
+		i = j
+	}
+	return groups
+}
+
+// uncoveredRanges 合并未执行语句块的行范围，相邻或重叠的范围合并为一个
+func uncoveredRanges(blocks []Block) []LineRange {
+	ranges := make([]LineRange, 0)
+	for _, block := range blocks {
+		if block.Count > 0 || block.NumStmt == 0 {
+			continue
+		}
+		if n := len(ranges); n > 0 && block.StartLine <= ranges[n-1].End+1 {
+			if block.EndLine > ranges[n-1].End {
+				ranges[n-1].End = block.EndLine
+			}
+			continue
+		}
+		ranges = append(ranges, LineRange{Start: block.StartLine, End: block.EndLine})
+	}
+	return ranges
+}
+
+// funcExtent 函数在源文件中的位置
+type funcExtent struct {
+	name      string
+	startLine int
+	startCol  int
+	endLine   int
+	endCol    int
+}
+
+// findFuncs 解析源文件中所有带函数体的函数声明
+func findFuncs(path string) ([]funcExtent, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source file: %w", err)
+	}
+	
+	extents := make([]funcExtent, 0)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		start := fset.Position(fn.Pos())
+		end := fset.Position(fn.End())
+		extents = append(extents, funcExtent{
+			name:      funcName(fn),
+			startLine: start.Line,
+			startCol:  start.Column,
+			endLine:   end.Line,
+			endCol:    end.Column,
+		})
+	}
+	return extents, nil
+}
+
+// funcName 返回函数名，方法带上接收者类型
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	
+	recv := fn.Recv.List[0].Type
+	pointer := false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		pointer = true
+		recv = star.X
+	}
+	// 泛型接收者 T[K] 只保留类型名
+	switch expr := recv.(type) {
+	case *ast.IndexExpr:
+		recv = expr.X
+	case *ast.IndexListExpr:
+		recv = expr.X
+	}
+	
+	typeName := "?"
+	if ident, ok := recv.(*ast.Ident); ok {
+		typeName = ident.Name
+	}
+	if pointer {
+		return fmt.Sprintf("(*%s).%s", typeName, fn.Name.Name)
+	}
+	return typeName + "." + fn.Name.Name
+}
+
+// blocksInExtent 返回位于函数范围内的语句块
+func blocksInExtent(blocks []Block, extent funcExtent) []Block {
+	inside := make([]Block, 0)
+	for _, block := range blocks {
+		if block.StartLine > extent.endLine || (block.StartLine == extent.endLine && block.StartCol >= extent.endCol) {
+			continue
+		}
+		if block.EndLine < extent.startLine || (block.EndLine == extent.startLine && block.EndCol <= extent.startCol) {
+			continue
+		}
+		inside = append(inside, block)
+	}
+	return inside
+}
+
+// sourcePath 将覆盖率文件中的导入路径形式文件名映射到模块根目录下的源文件，不属于该模块时返回 false
+func sourcePath(moduleRoot, modulePath, file string) (string, bool) {
+	if filepath.IsAbs(file) {
+		return file, true
+	}
+	if !strings.HasPrefix(file, modulePath+"/") {
+		return "", false
+	}
+	return filepath.Join(moduleRoot, filepath.FromSlash(strings.TrimPrefix(file, modulePath+"/"))), true
+}
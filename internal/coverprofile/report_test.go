@@ -0,0 +1,173 @@
+package coverprofile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleSource = `package parse
+
+type Parser struct{}
+
+func Parse(s string) int {
+	if s == "" {
+		return 0
+	}
+	return len(s)
+}
+
+func (p *Parser) Reset() {
+	p.clear()
+}
+
+func (p *Parser) clear() {}
+`
+
+// 与 sampleSource 对应：Parse 中 if 分支未执行，Reset 未执行
+const sampleSourceProfile = `mode: set
+example.com/app/parse/parse.go:5.26,6.14 1 1
+example.com/app/parse/parse.go:6.14,8.3 1 0
+example.com/app/parse/parse.go:9.2,9.15 1 1
+example.com/app/parse/parse.go:12.25,14.2 1 0
+`
+
+// writeModule 在临时目录中创建包含 go.mod 和 parse/parse.go 的模块
+func writeModule(t *testing.T) string {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "parse"), 0755); err != nil {
+		t.Fatalf("Failed to create package dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "parse", "parse.go"), []byte(sampleSource), 0644); err != nil {
+		t.Fatalf("Failed to write source file: %v", err)
+	}
+	return root
+}
+
+// TestProfile_Files 测试按文件汇总覆盖率和未覆盖行范围
+func TestProfile_Files(t *testing.T) {
+	// Arrange
+	profile, err := Parse(strings.NewReader(sampleSourceProfile))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	files := profile.Files()
+
+	// Assert
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(files))
+	}
+	file := files[0]
+	if file.Covered != 2 || file.Total != 4 || file.Percent != 50 {
+		t.Errorf("Unexpected file coverage: %+v", file)
+	}
+	expected := []LineRange{{Start: 6, End: 8}, {Start: 12, End: 14}}
+	if len(file.Uncovered) != len(expected) || file.Uncovered[0] != expected[0] || file.Uncovered[1] != expected[1] {
+		t.Errorf("Expected uncovered ranges %v, got %v", expected, file.Uncovered)
+	}
+	if !MatchFile(file.File, "parse/parse.go") || !MatchFile(file.File, "parse.go") || MatchFile(file.File, "arse.go") {
+		t.Error("Unexpected file matching result")
+	}
+}
+
+// TestProfile_Functions 测试根据源码按函数汇总覆盖率
+func TestProfile_Functions(t *testing.T) {
+	// Arrange
+	root := writeModule(t)
+	profile, err := Parse(strings.NewReader(sampleSourceProfile))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	functions, sourceErrors, err := profile.Functions(root)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sourceErrors) != 0 {
+		t.Errorf("Expected no source errors, got %+v", sourceErrors)
+	}
+	if len(functions) != 3 {
+		t.Fatalf("Expected 3 functions, got %+v", functions)
+	}
+	parse := functions[0]
+	if parse.Name != "Parse" || parse.StartLine != 5 || parse.EndLine != 10 || parse.Covered != 2 || parse.Total != 3 {
+		t.Errorf("Unexpected Parse coverage: %+v", parse)
+	}
+	if len(parse.Uncovered) != 1 || parse.Uncovered[0] != (LineRange{Start: 6, End: 8}) {
+		t.Errorf("Unexpected uncovered ranges for Parse: %v", parse.Uncovered)
+	}
+	reset := functions[1]
+	if reset.Name != "(*Parser).Reset" || reset.Percent != 0 {
+		t.Errorf("Unexpected Reset coverage: %+v", reset)
+	}
+	if !MatchFunc(reset.Name, "Reset") || MatchFunc(reset.Name, "eset") {
+		t.Error("Unexpected function matching result")
+	}
+	if functions[2].Total != 0 {
+		t.Errorf("Expected empty function without statements, got %+v", functions[2])
+	}
+}
+
+// TestProfile_Functions_SourceErrors 测试源码缺失或无法解析的文件单独报告，不影响其他文件
+func TestProfile_Functions_SourceErrors(t *testing.T) {
+	// Arrange
+	root := writeModule(t)
+	if err := os.WriteFile(filepath.Join(root, "parse", "broken.go"), []byte("package parse\n\nfunc Broken( {\n"), 0644); err != nil {
+		t.Fatalf("Failed to write source file: %v", err)
+	}
+	content := sampleSourceProfile +
+		"example.com/app/parse/broken.go:3.14,4.2 1 1\n" +
+		"example.com/app/parse/missing.go:3.14,4.2 1 0\n"
+	profile, err := Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	functions, sourceErrors, err := profile.Functions(root)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(functions) != 3 {
+		t.Errorf("Expected 3 functions from parse.go, got %+v", functions)
+	}
+	if len(sourceErrors) != 2 {
+		t.Fatalf("Expected 2 source errors, got %+v", sourceErrors)
+	}
+	if sourceErrors[0].File != "example.com/app/parse/broken.go" || sourceErrors[1].File != "example.com/app/parse/missing.go" {
+		t.Errorf("Unexpected source error files: %+v", sourceErrors)
+	}
+	for _, sourceError := range sourceErrors {
+		if sourceError.Error == "" {
+			t.Errorf("Expected error message for %s", sourceError.File)
+		}
+	}
+}
+
+// TestProfile_Functions_MissingGoMod 测试模块根目录没有 go.mod 时返回错误
+func TestProfile_Functions_MissingGoMod(t *testing.T) {
+	// Arrange
+	profile, err := Parse(strings.NewReader(sampleSourceProfile))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	_, _, err = profile.Functions(t.TempDir())
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for missing go.mod")
+	}
+}
@@ -0,0 +1,71 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestModulePath 测试读取 go.mod 中的模块路径
+func TestModulePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Plain module path",
+			content:  "module example.com/app\n\ngo 1.23\n",
+			expected: "example.com/app",
+		},
+		{
+			name:     "Quoted module path",
+			content:  "// comment\nmodule \"example.com/quoted\"\n",
+			expected: "example.com/quoted",
+		},
+		{
+			name:    "Missing module directive",
+			content: "go 1.23\n",
+			wantErr: true,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			root := t.TempDir()
+			if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write go.mod: %v", err)
+			}
+			
+			// Act
+			modulePath, err := ModulePath(root)
+			
+			// Assert
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got module path %q", modulePath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if modulePath != tt.expected {
+				t.Errorf("Expected module path %q, got %q", tt.expected, modulePath)
+			}
+		})
+	}
+}
+
+// TestModulePath_MissingGoMod 测试目录中没有 go.mod 时返回错误
+func TestModulePath_MissingGoMod(t *testing.T) {
+	// Act
+	_, err := ModulePath(t.TempDir())
+	
+	// Assert
+	if err == nil {
+		t.Error("Expected error for missing go.mod")
+	}
+}
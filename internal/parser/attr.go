@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AttributeFilter 测试属性过滤条件，Value 为空时只要求测试带有该属性
+type AttributeFilter struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// AttributeGroup 按某个属性值分组的测试统计
+type AttributeGroup struct {
+	Key         string   `json:"key"`
+	Value       string   `json:"value"` // 没有该属性的测试归入空值分组
+	Total       int      `json:"total"`
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	Skipped     int      `json:"skipped"`
+	TimedOut    int      `json:"timed_out"`
+	Tests       []string `json:"tests"`
+	FailedTests []string `json:"failed_tests"`
+}
+
+// ParseAttributeFilters 解析 "key=value" 或 "key" 格式的过滤条件
+func ParseAttributeFilters(specs []string) ([]AttributeFilter, error) {
+	filters := make([]AttributeFilter, 0, len(specs))
+	for _, spec := range specs {
+		key, value, _ := strings.Cut(spec, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid attribute filter %q: expected key=value or key", spec)
+		}
+		filters = append(filters, AttributeFilter{Key: key, Value: strings.TrimSpace(value)})
+	}
+	return filters, nil
+}
+
+// setAttribute 记录 t.Attr 设置的属性，同名属性以最后一次为准
+func (d *TestDetail) setAttribute(key, value string) {
+	if d.Attributes == nil {
+		d.Attributes = make(map[string]string)
+	}
+	d.Attributes[key] = value
+}
+
+// MatchesAttributes 判断测试是否满足所有过滤条件
+func (d *TestDetail) MatchesAttributes(filters []AttributeFilter) bool {
+	for _, filter := range filters {
+		value, exists := d.Attributes[filter.Key]
+		if !exists || (filter.Value != "" && value != filter.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestsWithAttributes 返回满足所有过滤条件的测试，按包名和测试名排序；没有过滤条件时返回全部测试
+func (r *TestResult) TestsWithAttributes(filters []AttributeFilter) []*TestDetail {
+	details := make([]*TestDetail, 0)
+	for _, detail := range r.TestDetails {
+		if detail.MatchesAttributes(filters) {
+			details = append(details, detail)
+		}
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return TestKey(details[i].Package, details[i].Name) < TestKey(details[j].Package, details[j].Name)
+	})
+	return details
+}
+
+// GroupByAttribute 按属性值分组统计测试，分组按属性值排序，没有该属性的测试放在最后
+func GroupByAttribute(details []*TestDetail, key string) []AttributeGroup {
+	groups := make([]AttributeGroup, 0)
+	index := make(map[string]int)
+	for _, detail := range details {
+		value := detail.Attributes[key]
+		i, exists := index[value]
+		if !exists {
+			i = len(groups)
+			index[value] = i
+			groups = append(groups, AttributeGroup{
+				Key:         key,
+				Value:       value,
+				Tests:       make([]string, 0),
+				FailedTests: make([]string, 0),
+			})
+		}
+		group := &groups[i]
+		name := TestKey(detail.Package, detail.Name)
+		group.Tests = append(group.Tests, name)
+		group.Total++
+		switch detail.Status {
+		case "pass":
+			group.Passed++
+		case "fail":
+			group.Failed++
+			group.FailedTests = append(group.FailedTests, name)
+		case "skip":
+			group.Skipped++
+		case "timeout":
+			group.TimedOut++
+		}
+	}
+	
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Value == "") != (groups[j].Value == "") {
+			return groups[j].Value == ""
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
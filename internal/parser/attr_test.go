@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_Attributes 测试文本日志中的 === ATTR 行
+func TestParseTestTextLog_Attributes(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestCharge
+=== ATTR  TestCharge owner payments
+=== ATTR  TestCharge kind integration
+=== ATTR  TestCharge note needs a live sandbox
+--- FAIL: TestCharge (0.10s)
+=== RUN   TestRefund
+=== ATTR  TestRefund owner payments
+--- PASS: TestRefund (0.00s)
+=== RUN   TestLogin
+=== ATTR  TestLogin owner auth
+--- PASS: TestLogin (0.00s)
+FAIL
+FAIL	example.com/app	0.120s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	charge := result.TestDetails["example.com/app.TestCharge"]
+	if charge.Attributes["owner"] != "payments" || charge.Attributes["kind"] != "integration" || charge.Attributes["note"] != "needs a live sandbox" {
+		t.Errorf("Unexpected attributes: %v", charge.Attributes)
+	}
+	if strings.Contains(charge.Output, "=== ATTR") {
+		t.Errorf("Expected ATTR lines not to be kept as output, got %q", charge.Output)
+	}
+	
+	filters, _ := ParseAttributeFilters([]string{"owner=payments"})
+	matched := result.TestsWithAttributes(filters)
+	if len(matched) != 2 || matched[0].Name != "TestCharge" || matched[1].Name != "TestRefund" {
+		t.Errorf("Expected payments tests, got %+v", matched)
+	}
+}
+
+// TestParseTestLog_Attributes 测试 JSON 日志中的 attr 事件
+func TestParseTestLog_Attributes(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"attr","Package":"example","Test":"TestA","Key":"owner","Value":"payments"}
+{"Action":"pass","Package":"example","Test":"TestA","Elapsed":0}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detail := result.TestDetails["example.TestA"]; detail.Attributes["owner"] != "payments" || detail.Status != "pass" {
+		t.Errorf("Unexpected detail: %+v", detail)
+	}
+}
+
+// TestGroupByAttribute 测试按属性值分组统计，没有该属性的测试排在最后
+func TestGroupByAttribute(t *testing.T) {
+	// Arrange
+	details := []*TestDetail{
+		{Package: "p", Name: "TestA", Status: "fail", Attributes: map[string]string{"owner": "payments"}},
+		{Package: "p", Name: "TestB", Status: "pass"},
+		{Package: "p", Name: "TestC", Status: "pass", Attributes: map[string]string{"owner": "auth"}},
+		{Package: "p", Name: "TestD", Status: "skip", Attributes: map[string]string{"owner": "payments"}},
+	}
+
+	// Act
+	groups := GroupByAttribute(details, "owner")
+
+	// Assert
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %+v", groups)
+	}
+	if groups[0].Value != "auth" || groups[1].Value != "payments" || groups[2].Value != "" {
+		t.Errorf("Unexpected group order: %+v", groups)
+	}
+	payments := groups[1]
+	if payments.Total != 2 || payments.Failed != 1 || payments.Skipped != 1 || payments.FailedTests[0] != "p.TestA" {
+		t.Errorf("Unexpected payments group: %+v", payments)
+	}
+}
+
+// TestParseAttributeFilters_Invalid 测试空键的过滤条件
+func TestParseAttributeFilters_Invalid(t *testing.T) {
+	// Act
+	_, err := ParseAttributeFilters([]string{"=payments"})
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error for empty key")
+	}
+}
@@ -0,0 +1,264 @@
+package parser
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultSignificanceLevel 基准测试比较默认的显著性水平，与 benchstat 一致
+const DefaultSignificanceLevel = 0.05
+
+// BenchmarkStats 一组基准测试样本（-count=N）的统计值
+type BenchmarkStats struct {
+	Samples int     `json:"samples"`
+	Median  float64 `json:"median"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+// BenchmarkDelta 同一基准测试同一指标在 base 和 head 之间的变化
+type BenchmarkDelta struct {
+	Package      string          `json:"package"`
+	Name         string          `json:"name"`
+	Procs        int             `json:"procs"`
+	Unit         string          `json:"unit"`
+	Base         *BenchmarkStats `json:"base,omitempty"`
+	Head         *BenchmarkStats `json:"head,omitempty"`
+	DeltaPercent float64         `json:"delta_percent"` // (head - base) / base 的中位数变化百分比
+	PValue       float64         `json:"p_value"`
+	Significant  bool            `json:"significant"`
+	// Verdict 取值：unchanged、improved、regressed、changed（方向未知的自定义指标）、base_only、head_only
+	Verdict string `json:"verdict"`
+}
+
+// BenchmarkComparison 两份日志的基准测试比较结果
+type BenchmarkComparison struct {
+	Alpha        float64          `json:"alpha"`
+	Deltas       []BenchmarkDelta `json:"deltas"`
+	Regressions  int              `json:"regressions"`
+	Improvements int              `json:"improvements"`
+}
+
+// benchmarkKey 基准测试比较的分组键
+type benchmarkKey struct {
+	pkg   string
+	name  string
+	procs int
+	unit  string
+}
+
+// CompareBenchmarks 比较 base 和 head 两次运行的基准测试，按包、名称、GOMAXPROCS 和指标单位分组，
+// 使用 Mann-Whitney U 检验判断中位数变化是否显著；alpha 不大于 0 时使用 DefaultSignificanceLevel
+func CompareBenchmarks(base, head *TestResult, alpha float64) *BenchmarkComparison {
+	if alpha <= 0 {
+		alpha = DefaultSignificanceLevel
+	}
+	
+	order := make([]benchmarkKey, 0)
+	baseSamples := groupBenchmarkSamples(base.Benchmarks, &order)
+	headSamples := groupBenchmarkSamples(head.Benchmarks, &order)
+	
+	comparison := &BenchmarkComparison{
+		Alpha:  alpha,
+		Deltas: make([]BenchmarkDelta, 0, len(order)),
+	}
+	for _, key := range order {
+		delta := BenchmarkDelta{
+			Package: key.pkg,
+			Name:    key.name,
+			Procs:   key.procs,
+			Unit:    key.unit,
+			PValue:  1,
+		}
+		baseValues, headValues := baseSamples[key], headSamples[key]
+		if len(baseValues) > 0 {
+			delta.Base = newBenchmarkStats(baseValues)
+		}
+		if len(headValues) > 0 {
+			delta.Head = newBenchmarkStats(headValues)
+		}
+		
+		switch {
+		case delta.Head == nil:
+			delta.Verdict = "base_only"
+		case delta.Base == nil:
+			delta.Verdict = "head_only"
+		default:
+			if delta.Base.Median != 0 {
+				delta.DeltaPercent = (delta.Head.Median - delta.Base.Median) / delta.Base.Median * 100
+			}
+			delta.PValue = mannWhitneyUTest(baseValues, headValues)
+			delta.Significant = delta.PValue < alpha && delta.Head.Median != delta.Base.Median
+			delta.Verdict = benchmarkVerdict(key.unit, delta)
+		}
+		
+		switch delta.Verdict {
+		case "regressed":
+			comparison.Regressions++
+		case "improved":
+			comparison.Improvements++
+		}
+		comparison.Deltas = append(comparison.Deltas, delta)
+	}
+	
+	return comparison
+}
+
+// groupBenchmarkSamples 按分组键收集每个指标的样本，并按首次出现顺序记录分组键
+func groupBenchmarkSamples(benchmarks []Benchmark, order *[]benchmarkKey) map[benchmarkKey][]float64 {
+	samples := make(map[benchmarkKey][]float64)
+	seen := make(map[benchmarkKey]bool)
+	for _, key := range *order {
+		seen[key] = true
+	}
+	
+	for _, benchmark := range benchmarks {
+		for _, metric := range benchmark.Metrics {
+			key := benchmarkKey{pkg: benchmark.Package, name: benchmark.Name, procs: benchmark.Procs, unit: metric.Unit}
+			samples[key] = append(samples[key], metric.Value)
+			if !seen[key] {
+				seen[key] = true
+				*order = append(*order, key)
+			}
+		}
+	}
+	return samples
+}
+
+// newBenchmarkStats 计算样本的中位数、最小值和最大值
+func newBenchmarkStats(values []float64) *BenchmarkStats {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return &BenchmarkStats{
+		Samples: n,
+		Median:  median,
+		Min:     sorted[0],
+		Max:     sorted[n-1],
+	}
+}
+
+// benchmarkVerdict 根据指标方向判断变化是改进还是退化：
+// 速率单位（如 MB/s）越高越好，时间、内存和分配次数越低越好，其他自定义单位方向未知
+func benchmarkVerdict(unit string, delta BenchmarkDelta) string {
+	if !delta.Significant {
+		return "unchanged"
+	}
+	
+	increased := delta.Head.Median > delta.Base.Median
+	switch {
+	case strings.HasSuffix(unit, "/s"):
+		if increased {
+			return "improved"
+		}
+		return "regressed"
+	case unit == "ns/op" || unit == "sec/op" || unit == "B/op" || unit == "allocs/op":
+		if increased {
+			return "regressed"
+		}
+		return "improved"
+	default:
+		return "changed"
+	}
+}
+
+// mannWhitneyUTest 返回双侧 Mann-Whitney U 检验的 p 值；
+// 样本没有并列值且规模较小时使用精确分布，否则使用带并列校正的正态近似
+func mannWhitneyUTest(x, y []float64) float64 {
+	m, n := len(x), len(y)
+	if m == 0 || n == 0 {
+		return 1
+	}
+	
+	type sample struct {
+		value float64
+		fromX bool
+	}
+	all := make([]sample, 0, m+n)
+	for _, v := range x {
+		all = append(all, sample{value: v, fromX: true})
+	}
+	for _, v := range y {
+		all = append(all, sample{value: v})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+	
+	// 计算秩和，并列值取平均秩
+	rankSumX := 0.0
+	tieCorrection := 0.0
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromX {
+				rankSumX += rank
+			}
+		}
+		ties := float64(j - i)
+		tieCorrection += ties*ties*ties - ties
+		i = j
+	}
+	
+	u := rankSumX - float64(m*(m+1))/2
+	u = math.Min(u, float64(m*n)-u)
+	
+	if tieCorrection == 0 && m+n <= 50 {
+		return mannWhitneyExactP(m, n, u)
+	}
+	
+	total := float64(m + n)
+	mean := float64(m*n) / 2
+	variance := float64(m*n) / 12 * ((total + 1) - tieCorrection/(total*(total-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		return 1
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// mannWhitneyExactP 使用 U 统计量的精确分布计算双侧 p 值
+func mannWhitneyExactP(m, n int, u float64) float64 {
+	memo := make(map[[2]int][]float64)
+	var distribution func(m, n int) []float64
+	distribution = func(m, n int) []float64 {
+		if counts, exists := memo[[2]int{m, n}]; exists {
+			return counts
+		}
+		counts := make([]float64, m*n+1)
+		if m == 0 || n == 0 {
+			counts[0] = 1
+		} else {
+			// 最大值来自 x 时贡献 n，来自 y 时贡献 0
+			for value, count := range distribution(m-1, n) {
+				counts[value+n] += count
+			}
+			for value, count := range distribution(m, n-1) {
+				counts[value] += count
+			}
+		}
+		memo[[2]int{m, n}] = counts
+		return counts
+	}
+	
+	counts := distribution(m, n)
+	total, cumulative := 0.0, 0.0
+	for value, count := range counts {
+		total += count
+		if float64(value) <= u {
+			cumulative += count
+		}
+	}
+	return math.Min(1, 2*cumulative/total)
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"math"
+	"testing"
+)
+
+// newBenchmarkResult 根据每个样本的 ns/op 构建只包含基准测试的结果
+func newBenchmarkResult(name string, nsPerOp ...float64) *TestResult {
+	result := &TestResult{Benchmarks: make([]Benchmark, 0)}
+	for _, value := range nsPerOp {
+		result.Benchmarks = append(result.Benchmarks, Benchmark{
+			Package:    "example",
+			Name:       name,
+			Procs:      8,
+			Iterations: 1000,
+			Metrics:    []BenchmarkMetric{{Value: value, Unit: "ns/op"}},
+		})
+	}
+	return result
+}
+
+// TestCompareBenchmarks_Regression 测试显著变慢的基准测试被判定为退化
+func TestCompareBenchmarks_Regression(t *testing.T) {
+	// Arrange
+	base := newBenchmarkResult("BenchmarkParse", 100, 101, 99, 98, 102)
+	head := newBenchmarkResult("BenchmarkParse", 120, 121, 119, 122, 118)
+
+	// Act
+	comparison := CompareBenchmarks(base, head, 0)
+
+	// Assert
+	if comparison.Alpha != DefaultSignificanceLevel {
+		t.Errorf("Expected default alpha, got %v", comparison.Alpha)
+	}
+	if len(comparison.Deltas) != 1 {
+		t.Fatalf("Expected 1 delta, got %d", len(comparison.Deltas))
+	}
+	delta := comparison.Deltas[0]
+	if delta.Base.Median != 100 || delta.Head.Median != 120 || delta.DeltaPercent != 20 {
+		t.Errorf("Unexpected medians or delta: %+v %+v %v", delta.Base, delta.Head, delta.DeltaPercent)
+	}
+	// 5 对 5 完全分离时精确双侧 p 值为 2/252
+	if math.Abs(delta.PValue-2.0/252) > 1e-9 {
+		t.Errorf("Expected p-value 2/252, got %v", delta.PValue)
+	}
+	if !delta.Significant || delta.Verdict != "regressed" || comparison.Regressions != 1 {
+		t.Errorf("Expected significant regression, got %+v", delta)
+	}
+}
+
+// TestCompareBenchmarks_TooFewSamples 测试样本不足时不判定为显著变化
+func TestCompareBenchmarks_TooFewSamples(t *testing.T) {
+	// Arrange
+	base := newBenchmarkResult("BenchmarkParse", 100)
+	head := newBenchmarkResult("BenchmarkParse", 200)
+
+	// Act
+	comparison := CompareBenchmarks(base, head, 0.05)
+
+	// Assert
+	delta := comparison.Deltas[0]
+	if delta.DeltaPercent != 100 {
+		t.Errorf("Expected +100%% delta, got %v", delta.DeltaPercent)
+	}
+	if delta.Significant || delta.Verdict != "unchanged" {
+		t.Errorf("Expected single samples not to be significant, got %+v", delta)
+	}
+}
+
+// TestCompareBenchmarks_MissingBenchmarks 测试只在一侧出现的基准测试
+func TestCompareBenchmarks_MissingBenchmarks(t *testing.T) {
+	// Arrange
+	base := newBenchmarkResult("BenchmarkOld", 100)
+	head := newBenchmarkResult("BenchmarkNew", 100)
+
+	// Act
+	comparison := CompareBenchmarks(base, head, 0)
+
+	// Assert
+	if len(comparison.Deltas) != 2 {
+		t.Fatalf("Expected 2 deltas, got %d", len(comparison.Deltas))
+	}
+	if comparison.Deltas[0].Verdict != "base_only" || comparison.Deltas[1].Verdict != "head_only" {
+		t.Errorf("Unexpected verdicts: %s, %s", comparison.Deltas[0].Verdict, comparison.Deltas[1].Verdict)
+	}
+}
+
+// TestMannWhitneyUTest_Ties 测试有并列值时使用正态近似
+func TestMannWhitneyUTest_Ties(t *testing.T) {
+	// Act
+	identical := mannWhitneyUTest([]float64{5, 5, 5}, []float64{5, 5, 5})
+	separated := mannWhitneyUTest([]float64{1, 1, 2, 2, 3, 3, 4, 4}, []float64{10, 10, 11, 11, 12, 12, 13, 13})
+
+	// Assert
+	if identical != 1 {
+		t.Errorf("Expected p-value 1 for identical samples, got %v", identical)
+	}
+	if separated >= 0.01 {
+		t.Errorf("Expected small p-value for separated samples, got %v", separated)
+	}
+}
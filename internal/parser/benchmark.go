@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BenchmarkMetric 基准测试的一项指标，如 98765 ns/op、12 allocs/op 或 b.ReportMetric 的自定义单位
+type BenchmarkMetric struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// Benchmark 一条基准测试结果，-count=N 时同一基准测试有 N 条结果
+type Benchmark struct {
+	Package    string            `json:"package"`
+	Name       string            `json:"name"`  // 不含 -N 后缀
+	Procs      int               `json:"procs"` // GOMAXPROCS 后缀，没有后缀时为 1
+	Iterations int64             `json:"iterations"`
+	Metrics    []BenchmarkMetric `json:"metrics"`
+	Goos       string            `json:"goos,omitempty"`
+	Goarch     string            `json:"goarch,omitempty"`
+	CPU        string            `json:"cpu,omitempty"`
+}
+
+// Metric 返回指定单位的指标值
+func (b Benchmark) Metric(unit string) (float64, bool) {
+	for _, metric := range b.Metrics {
+		if metric.Unit == unit {
+			return metric.Value, true
+		}
+	}
+	return 0, false
+}
+
+var (
+	// BenchmarkParse-8   	   12345	     98765 ns/op	    4096 B/op	      12 allocs/op
+	benchmarkLinePattern = regexp.MustCompile(`^(Benchmark\S*?)(?:-(\d+))?\s+(\d+)\s+(.+)$`)
+	// 只有名称的行，结果在下一行（-v 模式或 test2json 拆分的输出）
+	benchmarkNamePattern = regexp.MustCompile(`^(Benchmark\S*?)(?:-(\d+))?\s*$`)
+	// 名称行之后的结果行
+	benchmarkValuesPattern = regexp.MustCompile(`^\s*(\d+)\s+(.+)$`)
+	// goos: linux / goarch: amd64 / pkg: example.com/app / cpu: ...
+	benchmarkHeaderPattern = regexp.MustCompile(`^(goos|goarch|pkg|cpu): (.+)$`)
+)
+
+// benchmarkCollector 逐行收集基准测试结果及 goos/goarch/pkg/cpu 头部
+type benchmarkCollector struct {
+	pkg    string
+	goos   string
+	goarch string
+	cpu    string
+	
+	pendingName  string
+	pendingProcs string
+}
+
+// processLine 处理一行输出，返回解析出的基准测试结果以及该行是否属于基准测试输出
+func (c *benchmarkCollector) processLine(line string) (*Benchmark, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	
+	if matches := benchmarkHeaderPattern.FindStringSubmatch(line); matches != nil {
+		value := strings.TrimSpace(matches[2])
+		switch matches[1] {
+		case "goos":
+			c.goos = value
+		case "goarch":
+			c.goarch = value
+		case "pkg":
+			c.pkg = value
+		case "cpu":
+			c.cpu = value
+		}
+		return nil, true
+	}
+	
+	if matches := benchmarkLinePattern.FindStringSubmatch(line); matches != nil {
+		if benchmark := c.newBenchmark(matches[1], matches[2], matches[3], matches[4]); benchmark != nil {
+			c.pendingName = ""
+			return benchmark, true
+		}
+	}
+	
+	if matches := benchmarkNamePattern.FindStringSubmatch(line); matches != nil {
+		c.pendingName = matches[1]
+		c.pendingProcs = matches[2]
+		return nil, true
+	}
+	
+	if c.pendingName != "" {
+		matches := benchmarkValuesPattern.FindStringSubmatch(line)
+		if matches != nil {
+			if benchmark := c.newBenchmark(c.pendingName, c.pendingProcs, matches[1], matches[2]); benchmark != nil {
+				c.pendingName = ""
+				return benchmark, true
+			}
+		}
+	}
+	
+	return nil, false
+}
+
+// newBenchmark 根据名称、GOMAXPROCS 后缀、迭代次数和指标文本构建结果，指标无法解析时返回 nil
+func (c *benchmarkCollector) newBenchmark(name, procs, iterations, metricsText string) *Benchmark {
+	fields := strings.Fields(metricsText)
+	if len(fields) < 2 || len(fields)%2 != 0 {
+		return nil
+	}
+	
+	benchmark := &Benchmark{
+		Package: c.pkg,
+		Name:    name,
+		Procs:   1,
+		Metrics: make([]BenchmarkMetric, 0, len(fields)/2),
+		Goos:    c.goos,
+		Goarch:  c.goarch,
+		CPU:     c.cpu,
+	}
+	if procs != "" {
+		benchmark.Procs, _ = strconv.Atoi(procs)
+	}
+	benchmark.Iterations, _ = strconv.ParseInt(iterations, 10, 64)
+	
+	for i := 0; i < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return nil
+		}
+		benchmark.Metrics = append(benchmark.Metrics, BenchmarkMetric{Value: value, Unit: fields[i+1]})
+	}
+	return benchmark
+}
@@ -0,0 +1,280 @@
+package parser
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const benchmarkTextLog = `goos: linux
+goarch: amd64
+pkg: example.com/app/parser
+cpu: Intel(R) Core(TM) i7-9750H CPU @ 2.60GHz
+BenchmarkParse-8         	   12345	     98765 ns/op	    4096 B/op	      12 allocs/op
+BenchmarkParse/large-8   	     100	  12000000 ns/op
+BenchmarkCustom          	    1000	      1234 ns/op	         5.500 widgets/op
+PASS
+ok  	example.com/app/parser	3.456s`
+
+// TestParseTestTextLog_Benchmarks 测试解析基准测试结果、GOMAXPROCS 后缀和自定义指标
+func TestParseTestTextLog_Benchmarks(t *testing.T) {
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(benchmarkTextLog))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Benchmarks) != 3 {
+		t.Fatalf("Expected 3 benchmarks, got %d", len(result.Benchmarks))
+	}
+	first := result.Benchmarks[0]
+	if first.Name != "BenchmarkParse" || first.Procs != 8 || first.Iterations != 12345 {
+		t.Errorf("Unexpected benchmark: %+v", first)
+	}
+	if first.Package != "example.com/app/parser" || first.Goos != "linux" || first.Goarch != "amd64" || !strings.HasPrefix(first.CPU, "Intel") {
+		t.Errorf("Unexpected benchmark environment: %+v", first)
+	}
+	if len(first.Metrics) != 3 {
+		t.Fatalf("Expected 3 metrics, got %+v", first.Metrics)
+	}
+	if value, ok := first.Metric("allocs/op"); !ok || value != 12 {
+		t.Errorf("Expected 12 allocs/op, got %v", value)
+	}
+	if result.Benchmarks[1].Name != "BenchmarkParse/large" {
+		t.Errorf("Expected sub-benchmark name, got %s", result.Benchmarks[1].Name)
+	}
+	custom := result.Benchmarks[2]
+	if custom.Procs != 1 {
+		t.Errorf("Expected procs 1 without suffix, got %d", custom.Procs)
+	}
+	if value, ok := custom.Metric("widgets/op"); !ok || value != 5.5 {
+		t.Errorf("Expected custom metric 5.5 widgets/op, got %v", value)
+	}
+	if result.TotalTests != 3 || result.PassedTests != 3 {
+		t.Errorf("Expected each benchmark to count as a passed test like in -v logs, got total=%d passed=%d", result.TotalTests, result.PassedTests)
+	}
+}
+
+// TestParseTestTextLog_BenchmarkPackageFromSummary 测试没有 pkg: 头部时从包汇总行获取包名
+func TestParseTestTextLog_BenchmarkPackageFromSummary(t *testing.T) {
+	// Arrange
+	log := `BenchmarkA-4   	 1000	  1000 ns/op
+ok  	example/a	1.0s
+BenchmarkB-4   	 2000	  500 ns/op
+ok  	example/b	1.0s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Benchmarks) != 2 {
+		t.Fatalf("Expected 2 benchmarks, got %d", len(result.Benchmarks))
+	}
+	if result.Benchmarks[0].Package != "example/a" || result.Benchmarks[1].Package != "example/b" {
+		t.Errorf("Unexpected packages: %s, %s", result.Benchmarks[0].Package, result.Benchmarks[1].Package)
+	}
+}
+
+// TestParseTestLog_Benchmarks 测试 JSON 日志中的基准测试，包括名称和结果被拆成两个输出事件的情况
+func TestParseTestLog_Benchmarks(t *testing.T) {
+	// Arrange
+	log := `{"Action":"output","Package":"example","Output":"goos: darwin\n"}
+{"Action":"output","Package":"example","Output":"goarch: arm64\n"}
+{"Action":"output","Package":"example","Output":"pkg: example\n"}
+{"Action":"run","Package":"example","Test":"BenchmarkSplit"}
+{"Action":"output","Package":"example","Test":"BenchmarkSplit","Output":"BenchmarkSplit-10\n"}
+{"Action":"output","Package":"example","Test":"BenchmarkSplit","Output":"  500000\t      2100 ns/op\t     64 B/op\n"}
+{"Action":"bench","Package":"example","Test":"BenchmarkJoin","Output":"BenchmarkJoin-10   \t 1000000\t      1050 ns/op\n"}
+{"Action":"output","Package":"example","Output":"PASS\n"}
+{"Action":"pass","Package":"example","Elapsed":2.5}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Benchmarks) != 2 {
+		t.Fatalf("Expected 2 benchmarks, got %d", len(result.Benchmarks))
+	}
+	split := result.Benchmarks[0]
+	if split.Name != "BenchmarkSplit" || split.Procs != 10 || split.Iterations != 500000 || split.Goos != "darwin" {
+		t.Errorf("Unexpected benchmark: %+v", split)
+	}
+	if value, ok := split.Metric("B/op"); !ok || value != 64 {
+		t.Errorf("Expected 64 B/op, got %v", value)
+	}
+	if result.Benchmarks[1].Name != "BenchmarkJoin" || result.Benchmarks[1].Package != "example" {
+		t.Errorf("Unexpected benchmark: %+v", result.Benchmarks[1])
+	}
+}
+
+// TestParseTestTextLog_FailedBenchmark 测试失败的基准测试（--- FAIL 行没有耗时）有自己的详情，b.Fatal 输出不归属到之前的测试
+func TestParseTestTextLog_FailedBenchmark(t *testing.T) {
+	tests := []struct {
+		name       string
+		log        string
+		passedKeys []string
+	}{
+		{
+			name: "Non-verbose",
+			log: `--- FAIL: TestBad (0.00s)
+    bb_test.go:6: bad
+goos: linux
+goarch: amd64
+pkg: example.com/bb
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkA 	     100	         1.900 ns/op
+--- BENCH: BenchmarkA
+    bb_test.go:9: hello
+--- FAIL: BenchmarkB
+    bb_test.go:13: broken
+FAIL
+exit status 1
+FAIL	example.com/bb	0.002s`,
+			passedKeys: []string{"example.com/bb.BenchmarkA"},
+		},
+		{
+			name: "Verbose",
+			log: `=== RUN   TestBad
+    bb_test.go:6: bad
+--- FAIL: TestBad (0.00s)
+goos: linux
+goarch: amd64
+pkg: example.com/bb
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkA
+    bb_test.go:9: hello
+BenchmarkA 	     100	         1.290 ns/op
+BenchmarkB
+    bb_test.go:13: broken
+--- FAIL: BenchmarkB
+FAIL
+exit status 1
+FAIL	example.com/bb	0.002s`,
+			passedKeys: []string{"example.com/bb.BenchmarkA"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := ParseTestTextLog(strings.NewReader(tt.log))
+			
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			benchmark := result.TestDetails["example.com/bb.BenchmarkB"]
+			if benchmark == nil || benchmark.Status != "fail" || benchmark.Kind != KindBenchmark {
+				t.Fatalf("Expected failed benchmark detail, got %+v", benchmark)
+			}
+			if !strings.Contains(benchmark.Error, "bb_test.go:13: broken") {
+				t.Errorf("Expected b.Fatal message in benchmark error, got %q", benchmark.Error)
+			}
+			if previous := result.TestDetails["example.com/bb.TestBad"]; strings.Contains(previous.Output, "broken") || strings.Contains(previous.Output, "hello") {
+				t.Errorf("Expected benchmark output not to be attached to TestBad, got %q", previous.Output)
+			}
+			if result.FailedTests != 2 || result.PassedTests != len(tt.passedKeys) || result.Incomplete {
+				t.Errorf("Unexpected counts: failed=%d passed=%d incomplete=%v", result.FailedTests, result.PassedTests, result.Incomplete)
+			}
+			for _, key := range tt.passedKeys {
+				if detail := result.TestDetails[key]; detail == nil || detail.Status != "pass" || !strings.Contains(detail.Output, "hello") {
+					t.Errorf("Expected passed benchmark %s with its log output, got %+v", key, detail)
+				}
+			}
+		})
+	}
+}
+
+// TestParseTestLog_FailedBenchmark 测试 JSON 日志中通过的基准测试在结果行到达时结束，与文本解析的状态一致
+func TestParseTestLog_FailedBenchmark(t *testing.T) {
+	// Arrange
+	log := `{"Action":"start","Package":"example.com/bb"}
+{"Action":"output","Package":"example.com/bb","Output":"pkg: example.com/bb\n"}
+{"Action":"run","Package":"example.com/bb","Test":"BenchmarkA"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkA","Output":"=== RUN   BenchmarkA\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkA","Output":"BenchmarkA\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkA","Output":"BenchmarkA \t"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkA","Output":"     100\t         1.720 ns/op\n"}
+{"Action":"run","Package":"example.com/bb","Test":"BenchmarkB"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"=== RUN   BenchmarkB\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"BenchmarkB\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"    bb_test.go:13: broken\n","OutputType":"error"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"--- FAIL: BenchmarkB\n","OutputType":"frame"}
+{"Action":"fail","Package":"example.com/bb","Test":"BenchmarkB"}
+{"Action":"output","Package":"example.com/bb","Output":"FAIL\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/bb","Output":"exit status 1\n"}
+{"Action":"output","Package":"example.com/bb","Output":"FAIL\texample.com/bb\t0.003s\n","OutputType":"frame"}
+{"Action":"fail","Package":"example.com/bb","Elapsed":0.003}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detail := result.TestDetails["example.com/bb.BenchmarkA"]; detail.Status != "pass" || !strings.Contains(detail.Output, "1.720 ns/op") {
+		t.Errorf("Expected BenchmarkA to pass with its result line, got %+v", detail)
+	}
+	if detail := result.TestDetails["example.com/bb.BenchmarkB"]; detail.Status != "fail" || !strings.Contains(detail.Error, "broken") {
+		t.Errorf("Expected BenchmarkB to fail with b.Fatal message, got %+v", detail)
+	}
+	if result.PassedTests != 1 || result.FailedTests != 1 || len(result.Benchmarks) != 1 {
+		t.Errorf("Unexpected counts: passed=%d failed=%d benchmarks=%d", result.PassedTests, result.FailedTests, len(result.Benchmarks))
+	}
+}
+
+// TestParseBenchmarks_CountsAcrossFormats 测试同一次运行的非 -v、-v 文本日志和 JSON 日志中基准测试计数一致
+func TestParseBenchmarks_CountsAcrossFormats(t *testing.T) {
+	// Arrange
+	files := map[string]func(io.Reader) (*TestResult, error){
+		"ok_03.txt":  ParseTestTextLog,
+		"ok_04.txt":  ParseTestTextLog,
+		"ok_05.json": ParseTestLog,
+	}
+
+	for name, parse := range files {
+		t.Run(name, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("..", "..", "test_data", name))
+			if err != nil {
+				t.Fatalf("Failed to open test data: %v", err)
+			}
+			defer file.Close()
+
+			// Act
+			result, err := parse(file)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(result.Benchmarks) != 3 {
+				t.Errorf("Expected 3 benchmark results, got %d", len(result.Benchmarks))
+			}
+			// 非 -v 日志不打印通过的测试，只比较基准测试的计数
+			benchmarks := 0
+			for _, key := range result.PassedTestNames {
+				if result.TestDetails[key].Kind == KindBenchmark {
+					benchmarks++
+				}
+			}
+			if benchmarks != 4 {
+				t.Errorf("Expected 4 passed benchmarks including the parent, got %d of %v", benchmarks, result.PassedTestNames)
+			}
+			for _, key := range []string{"example.com/bm.BenchmarkFlat", "example.com/bm.BenchmarkSub", "example.com/bm.BenchmarkSub/small"} {
+				if detail := result.TestDetails[key]; detail == nil || detail.Status != "pass" || detail.Kind != KindBenchmark {
+					t.Errorf("Expected passed benchmark %s, got %+v", key, detail)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PackageCoverage 包的语句覆盖率，来自 ok 汇总行或 -v 模式下单独的 coverage: 行
+type PackageCoverage struct {
+	Package      string  `json:"package"`
+	Percent      float64 `json:"percent"`
+	NoStatements bool    `json:"no_statements"`   // coverage: [no statements]
+	Scope        string  `json:"scope,omitempty"` // -coverpkg 时 "of statements in" 之后的包模式
+}
+
+var (
+	// coverage: 78.3% of statements 或 coverage: 78.3% of statements in ./...
+	coveragePattern = regexp.MustCompile(`^coverage: ([0-9.]+)% of statements(?: in (.+))?$`)
+	// 	example.com/app/cmd		coverage: 0.0% of statements（go 1.22 起没有测试文件的包）
+	packageCoveragePattern = regexp.MustCompile(`^([^\s:]+)\s+(coverage: .+)$`)
+)
+
+// parseCoverage 解析 "coverage: ..." 文本，不是覆盖率时返回 nil
+func parseCoverage(pkg, text string) *PackageCoverage {
+	text = strings.TrimSpace(text)
+	if text == "coverage: [no statements]" {
+		return &PackageCoverage{Package: pkg, NoStatements: true}
+	}
+	matches := coveragePattern.FindStringSubmatch(text)
+	if matches == nil {
+		return nil
+	}
+	percent, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return nil
+	}
+	return &PackageCoverage{Package: pkg, Percent: percent, Scope: matches[2]}
+}
+
+// parseNoTestFilesCoverage 解析没有测试文件的包的覆盖率行，不是这种行时返回 nil
+func parseNoTestFilesCoverage(line string) *PackageCoverage {
+	matches := packageCoveragePattern.FindStringSubmatch(strings.TrimSpace(line))
+	if matches == nil {
+		return nil
+	}
+	return parseCoverage(matches[1], matches[2])
+}
+
+// coverageCollector 按包记录覆盖率，同一个包以最后一次出现为准，保持包首次出现的顺序
+type coverageCollector struct {
+	order    []string
+	packages map[string]*PackageCoverage
+}
+
+// newCoverageCollector 创建覆盖率收集器
+func newCoverageCollector() *coverageCollector {
+	return &coverageCollector{
+		order:    make([]string, 0),
+		packages: make(map[string]*PackageCoverage),
+	}
+}
+
+// record 记录一个包的覆盖率
+func (c *coverageCollector) record(coverage *PackageCoverage) {
+	if coverage == nil {
+		return
+	}
+	if _, exists := c.packages[coverage.Package]; !exists {
+		c.order = append(c.order, coverage.Package)
+	}
+	c.packages[coverage.Package] = coverage
+}
+
+// apply 将覆盖率写入结果
+func (c *coverageCollector) apply(result *TestResult) {
+	for _, pkg := range c.order {
+		result.Coverage = append(result.Coverage, *c.packages[pkg])
+	}
+}
+
+// CoverageFor 返回指定包的覆盖率
+func (r *TestResult) CoverageFor(pkg string) (PackageCoverage, bool) {
+	for _, coverage := range r.Coverage {
+		if coverage.Package == pkg {
+			return coverage, true
+		}
+	}
+	return PackageCoverage{}, false
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_Coverage 测试从包汇总行、单独的 coverage: 行和无测试文件的包中解析覆盖率
+func TestParseTestTextLog_Coverage(t *testing.T) {
+	// Arrange
+	log := `ok  	example.com/app/parse	0.031s	coverage: 78.3% of statements
+ok  	example.com/app/store	(cached)	coverage: [no statements]
+=== RUN   TestServe
+--- PASS: TestServe (0.00s)
+PASS
+coverage: 12.5% of statements in ./...
+ok  	example.com/app/server	0.120s
+	example.com/app/cmd		coverage: 0.0% of statements`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Coverage) != 4 {
+		t.Fatalf("Expected 4 coverage entries, got %+v", result.Coverage)
+	}
+	if coverage, ok := result.CoverageFor("example.com/app/parse"); !ok || coverage.Percent != 78.3 {
+		t.Errorf("Expected 78.3%% for parse, got %+v", coverage)
+	}
+	if coverage, ok := result.CoverageFor("example.com/app/store"); !ok || !coverage.NoStatements {
+		t.Errorf("Expected no statements for store, got %+v", coverage)
+	}
+	if coverage, ok := result.CoverageFor("example.com/app/server"); !ok || coverage.Percent != 12.5 || coverage.Scope != "./..." {
+		t.Errorf("Expected 12.5%% of ./... for server, got %+v", coverage)
+	}
+	if coverage, ok := result.CoverageFor("example.com/app/cmd"); !ok || coverage.Percent != 0 {
+		t.Errorf("Expected 0%% for cmd, got %+v", coverage)
+	}
+	if result.Packages[0] != "example.com/app/parse" {
+		t.Errorf("Expected package name without coverage suffix, got %q", result.Packages[0])
+	}
+}
+
+// TestParseTestTextLog_FailingPackageCoverage 测试失败包的覆盖率在 -v 和非 -v 输出中都会记录
+func TestParseTestTextLog_FailingPackageCoverage(t *testing.T) {
+	// Arrange
+	logs := map[string]string{
+		"verbose": "=== RUN   TestCV\n--- FAIL: TestCV (0.00s)\nFAIL\ncoverage: 75.0% of statements\nFAIL\texample.com/sm6/cv\t0.010s\n",
+		"quiet":   "--- FAIL: TestCV (0.00s)\nFAIL\ncoverage: 75.0% of statements\nFAIL\texample.com/sm6/cv\t0.010s\n",
+	}
+
+	for name, log := range logs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result, err := ParseTestTextLog(strings.NewReader(log))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if coverage, ok := result.CoverageFor("example.com/sm6/cv"); !ok || coverage.Percent != 75 {
+				t.Errorf("Expected 75%% for the failing package, got %+v", result.Coverage)
+			}
+			if result.PackageResults[0].Status != PackageFail {
+				t.Errorf("Expected failed package, got %q", result.PackageResults[0].Status)
+			}
+		})
+	}
+}
+
+// TestParseTestLog_Coverage 测试 JSON 日志中包级输出的覆盖率
+func TestParseTestLog_Coverage(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"pass","Package":"example","Test":"TestA","Elapsed":0}
+{"Action":"output","Package":"example","Output":"PASS\n"}
+{"Action":"output","Package":"example","Output":"coverage: 64.0% of statements\n"}
+{"Action":"output","Package":"example","Output":"ok  \texample\t0.010s\tcoverage: 64.0% of statements\n"}
+{"Action":"pass","Package":"example","Elapsed":0.01}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Coverage) != 1 || result.Coverage[0].Package != "example" || result.Coverage[0].Percent != 64 {
+		t.Errorf("Unexpected coverage: %+v", result.Coverage)
+	}
+}
@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BuildDiagnostic 编译诊断信息，对应编译器输出的一条 file:line:col: msg
+type BuildDiagnostic struct {
+	Package string   `json:"package"`
+	File    string   `json:"file"`
+	Line    int      `json:"line"`
+	Column  int      `json:"column"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"` // have (...)/want (...) 等续行
+}
+
+// String 返回与编译器输出一致的格式
+func (d BuildDiagnostic) String() string {
+	text := fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+	for _, detail := range d.Details {
+		text += "\n\t" + detail
+	}
+	return text
+}
+
+// PackageDiagnostics 单个包的编译诊断
+type PackageDiagnostics struct {
+	Package     string            `json:"package"`
+	Diagnostics []BuildDiagnostic `json:"diagnostics"`
+}
+
+var (
+	// # pkg 或 # pkg [pkg.test]；go vet 失败时头部为 # [pkg]
+	buildHeaderPattern = regexp.MustCompile(`^# (\S+)(?:\s+\[(\S+)\])?$`)
+	// 编译器诊断从行首开始，缩进的行属于测试输出或诊断续行
+	diagnosticPattern = regexp.MustCompile(`^(\S.*?):(\d+):(\d+):\s+(.+)$`)
+)
+
+// diagnosticCollector 收集编译诊断，并将 have/want 续行归属到上一条诊断
+type diagnosticCollector struct {
+	diagnostics    []BuildDiagnostic
+	currentPackage string
+	lastIndex      int
+}
+
+// newDiagnosticCollector 创建诊断收集器
+func newDiagnosticCollector() *diagnosticCollector {
+	return &diagnosticCollector{
+		diagnostics: make([]BuildDiagnostic, 0),
+		lastIndex:   -1,
+	}
+}
+
+// processLine 处理一行输出，属于编译诊断时返回 true；
+// 测试运行期间的输出属于测试（如测试打印的 config.yaml:3:5: ... 或缩进的 t.Log），不作为诊断
+func (c *diagnosticCollector) processLine(line string, testRunning bool) bool {
+	if testRunning {
+		c.lastIndex = -1
+		return false
+	}
+	trimmed := strings.TrimSpace(line)
+	
+	if matches := buildHeaderPattern.FindStringSubmatch(strings.TrimRight(line, "\r")); matches != nil {
+		c.currentPackage = buildPackage(matches[1], matches[2])
+		c.lastIndex = -1
+		return true
+	}
+	
+	if matches := diagnosticPattern.FindStringSubmatch(strings.TrimRight(line, "\r")); matches != nil {
+		lineNum, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		c.diagnostics = append(c.diagnostics, BuildDiagnostic{
+			Package: c.currentPackage,
+			File:    matches[1],
+			Line:    lineNum,
+			Column:  column,
+			Message: matches[4],
+		})
+		c.lastIndex = len(c.diagnostics) - 1
+		return true
+	}
+	
+	if c.lastIndex >= 0 && trimmed != "" && (line[0] == ' ' || line[0] == '\t') {
+		diagnostic := &c.diagnostics[c.lastIndex]
+		diagnostic.Details = append(diagnostic.Details, trimmed)
+		return true
+	}
+	
+	c.lastIndex = -1
+	return false
+}
+
+// buildPackage 返回编译单元所属的包，以 [pkg.test] 中的被测包为准，外部测试包 pkg_test 也归属到 pkg；
+// vet 头部 # [pkg] 的方括号会被去掉
+func buildPackage(pkg, testPkg string) string {
+	if testPkg != "" {
+		return strings.TrimSuffix(testPkg, ".test")
+	}
+	if strings.HasPrefix(pkg, "[") && strings.HasSuffix(pkg, "]") {
+		return pkg[1 : len(pkg)-1]
+	}
+	return pkg
+}
+
+// setImportPath 处理 test2json 编译事件的 ImportPath（"pkg" 或 "pkg [pkg.test]"），
+// 之后没有 # 头部的诊断归属到该包
+func (c *diagnosticCollector) setImportPath(importPath string) {
+	if importPath == "" {
+		return
+	}
+	pkg, testPkg, _ := strings.Cut(importPath, " [")
+	pkg = buildPackage(pkg, strings.TrimSuffix(testPkg, "]"))
+	if pkg != c.currentPackage {
+		c.currentPackage = pkg
+		c.lastIndex = -1
+	}
+}
+
+// assignPackage 处理 "FAIL pkg [build failed]"，没有 # 头部的诊断归属到该包
+func (c *diagnosticCollector) assignPackage(pkg string) {
+	for i := range c.diagnostics {
+		if c.diagnostics[i].Package == "" {
+			c.diagnostics[i].Package = pkg
+		}
+	}
+	c.currentPackage = ""
+	c.lastIndex = -1
+}
+
+// apply 将诊断写入结果，并创建一个 BuildError 失败测试以便总览能反映编译失败
+func (c *diagnosticCollector) apply(result *TestResult) {
+	if len(c.diagnostics) == 0 {
+		return
+	}
+	result.BuildDiagnostics = c.diagnostics
+	
+	groups := result.DiagnosticsByPackage()
+	packages := make([]string, 0, len(groups))
+	lines := make([]string, 0, len(c.diagnostics))
+	for _, group := range groups {
+		if group.Package == "" {
+			packages = append(packages, "(unknown package)")
+		} else {
+			packages = append(packages, group.Package)
+		}
+		for _, diagnostic := range group.Diagnostics {
+			lines = append(lines, diagnostic.String())
+		}
+	}
+	
+	buildErrorTest := "BuildError"
+	result.FailedTests++
+	result.FailedTestNames = append(result.FailedTestNames, buildErrorTest)
+	result.TestDetails[buildErrorTest] = &TestDetail{
+		Name:   buildErrorTest,
+		Kind:   KindTest,
+		Status: "fail",
+		Output: strings.Join(lines, "\n"),
+		Error:  fmt.Sprintf("Build failed: %d diagnostic(s) in %s", len(c.diagnostics), strings.Join(packages, ", ")),
+	}
+}
+
+// DiagnosticsByPackage 按包分组返回编译诊断，保持包首次出现的顺序
+func (r *TestResult) DiagnosticsByPackage() []PackageDiagnostics {
+	groups := make([]PackageDiagnostics, 0)
+	index := make(map[string]int)
+	for _, diagnostic := range r.BuildDiagnostics {
+		i, exists := index[diagnostic.Package]
+		if !exists {
+			i = len(groups)
+			index[diagnostic.Package] = i
+			groups = append(groups, PackageDiagnostics{Package: diagnostic.Package})
+		}
+		groups[i].Diagnostics = append(groups[i].Diagnostics, diagnostic)
+	}
+	return groups
+}
@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_BuildDiagnostics 测试编译诊断的结构化解析
+func TestParseTestTextLog_BuildDiagnostics(t *testing.T) {
+	// Arrange
+	testInput := `# example.com/app/store [example.com/app/store.test]
+store\upload_test.go:82:32: not enough arguments in call to client.Upload
+        have (string, models.UploadCallbacks)
+        want (string, models.UploadCallbacks, *models.FileUploadMetadata)
+store\upload_test.go:111:48: undefined: newClient
+FAIL    example.com/app/store [build failed]`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.BuildDiagnostics) != 2 {
+		t.Fatalf("Expected 2 diagnostics, got %d", len(result.BuildDiagnostics))
+	}
+	first := result.BuildDiagnostics[0]
+	if first.Package != "example.com/app/store" || first.File != `store\upload_test.go` ||
+		first.Line != 82 || first.Column != 32 || first.Message != "not enough arguments in call to client.Upload" {
+		t.Errorf("Unexpected first diagnostic: %+v", first)
+	}
+	if len(first.Details) != 2 || first.Details[0] != "have (string, models.UploadCallbacks)" ||
+		first.Details[1] != "want (string, models.UploadCallbacks, *models.FileUploadMetadata)" {
+		t.Errorf("Expected have/want details, got %v", first.Details)
+	}
+	if second := result.BuildDiagnostics[1]; len(second.Details) != 0 || second.Message != "undefined: newClient" {
+		t.Errorf("Unexpected second diagnostic: %+v", second)
+	}
+	if detail := result.TestDetails["BuildError"]; detail == nil || !strings.Contains(detail.Error, "example.com/app/store") {
+		t.Errorf("Expected BuildError mentioning the package, got %+v", detail)
+	}
+}
+
+// TestParseTestTextLog_BuildDiagnosticsExternalTestPackage 测试外部测试包的诊断归属
+func TestParseTestTextLog_BuildDiagnosticsExternalTestPackage(t *testing.T) {
+	// Arrange
+	testInput := `# example.com/app/store_test [example.com/app/store.test]
+store_test.go:5:2: "fmt" imported and not used
+FAIL    example.com/app/store [build failed]`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.BuildDiagnostics) != 1 || result.BuildDiagnostics[0].Package != "example.com/app/store" {
+		t.Errorf("Expected diagnostic owned by example.com/app/store, got %+v", result.BuildDiagnostics)
+	}
+}
+
+// TestParseTestTextLog_VetDiagnostics 测试 go vet 失败时 # [pkg] 头部的诊断归属
+func TestParseTestTextLog_VetDiagnostics(t *testing.T) {
+	// Arrange
+	testInput := `# [example.com/x]
+./x_test.go:10:2: fmt.Printf format %d has arg s of wrong type string
+FAIL	example.com/x [build failed]`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.BuildDiagnostics) != 1 || result.BuildDiagnostics[0].Package != "example.com/x" {
+		t.Fatalf("Expected vet diagnostic owned by example.com/x, got %+v", result.BuildDiagnostics)
+	}
+	grouped := result.DiagnosticsByPackage()
+	if len(grouped) != 1 || grouped[0].Package != "example.com/x" {
+		t.Errorf("Expected diagnostics grouped under example.com/x, got %+v", grouped)
+	}
+	if detail := result.TestDetails["BuildError"]; detail == nil || !strings.HasSuffix(detail.Error, "in example.com/x") {
+		t.Errorf("Expected BuildError naming example.com/x, got %+v", detail)
+	}
+}
+
+// TestParseTestTextLog_DiagnosticLikeTestOutput 测试运行中的测试在行首打印的 file:line:col: 输出不被当作编译诊断
+func TestParseTestTextLog_DiagnosticLikeTestOutput(t *testing.T) {
+	// Arrange
+	testInput := `=== RUN   TestCfg
+config.yaml:3:5: unexpected key
+    d_test.go:10: bad config
+--- FAIL: TestCfg (0.00s)
+FAIL
+FAIL	example.com/cfg	0.010s`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.BuildDiagnostics) != 0 {
+		t.Errorf("Expected no build diagnostics, got %+v", result.BuildDiagnostics)
+	}
+	if _, exists := result.TestDetails["BuildError"]; exists {
+		t.Error("Expected no BuildError test")
+	}
+	detail := result.TestDetails["example.com/cfg.TestCfg"]
+	if detail == nil || !strings.Contains(detail.Output, "d_test.go:10: bad config") || !strings.Contains(detail.Error, "d_test.go:10: bad config") {
+		t.Errorf("Expected the test's own log to stay with TestCfg, got %+v", detail)
+	}
+}
+
+// TestParseTestTextLog_BuildDiagnosticsFromTestData 测试 fail_01.txt 中多个包的诊断分组
+func TestParseTestTextLog_BuildDiagnosticsFromTestData(t *testing.T) {
+	// Arrange
+	file, err := os.Open(filepath.Join("..", "..", "test_data", "fail_01.txt"))
+	if err != nil {
+		t.Fatalf("Failed to open test data: %v", err)
+	}
+	defer file.Close()
+
+	// Act
+	result, err := ParseTestTextLog(file)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	groups := result.DiagnosticsByPackage()
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 packages with diagnostics, got %d", len(groups))
+	}
+	// 第一个包没有 # 头部，由 FAIL ... [build failed] 行确定归属
+	if groups[0].Package != "github.com/UritMedical/lingxi.stroe/internal/client" || len(groups[0].Diagnostics) != 5 {
+		t.Errorf("Expected 5 diagnostics for internal/client, got %s with %d", groups[0].Package, len(groups[0].Diagnostics))
+	}
+	if groups[1].Package != "github.com/UritMedical/lingxi.stroe/internal/client/client" || len(groups[1].Diagnostics) != 2 {
+		t.Errorf("Expected 2 diagnostics for internal/client/client, got %s with %d", groups[1].Package, len(groups[1].Diagnostics))
+	}
+	for _, diagnostic := range result.BuildDiagnostics {
+		if len(diagnostic.Details) != 2 {
+			t.Errorf("Expected have/want details for %s:%d, got %v", diagnostic.File, diagnostic.Line, diagnostic.Details)
+		}
+	}
+	if result.PassedTests != 8 {
+		t.Errorf("Expected PassedTests=8, got %d", result.PassedTests)
+	}
+}
+
+// TestParseTestLog_BuildDiagnosticsInStderr 测试JSON日志中混入的编译错误
+func TestParseTestLog_BuildDiagnosticsInStderr(t *testing.T) {
+	// Arrange
+	testInput := `# example.com/app [example.com/app.test]
+./app_test.go:10:2: undefined: foo
+{"Action":"output","Package":"example.com/app","Output":"FAIL\texample.com/app [build failed]\n"}
+{"Action":"fail","Package":"example.com/app","Elapsed":0}`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.BuildDiagnostics) != 1 || result.BuildDiagnostics[0].Package != "example.com/app" ||
+		result.BuildDiagnostics[0].File != "./app_test.go" {
+		t.Errorf("Unexpected diagnostics: %+v", result.BuildDiagnostics)
+	}
+	if result.FailedTests != 1 {
+		t.Errorf("Expected FailedTests=1, got %d", result.FailedTests)
+	}
+}
+
+// TestParseTestLog_BuildOutputEvents 测试 Go 1.24 起 JSON 模式中 build-output/build-fail 事件的编译诊断
+func TestParseTestLog_BuildOutputEvents(t *testing.T) {
+	// Arrange
+	testInput := `{"ImportPath":"example.com/app/store [example.com/app/store.test]","Action":"build-output","Output":"# example.com/app/store [example.com/app/store.test]\n"}
+{"ImportPath":"example.com/app/store [example.com/app/store.test]","Action":"build-output","Output":"store/upload_test.go:82:32: not enough arguments in call to client.Upload\n"}
+{"ImportPath":"example.com/app/store [example.com/app/store.test]","Action":"build-output","Output":"\thave (string)\n\twant (string, int)\n"}
+{"ImportPath":"example.com/app/store [example.com/app/store.test]","Action":"build-fail"}
+{"Time":"2025-01-01T00:00:00Z","Action":"start","Package":"example.com/app/store"}
+{"Time":"2025-01-01T00:00:00Z","Action":"output","Package":"example.com/app/store","Output":"FAIL\texample.com/app/store [build failed]\n"}
+{"Time":"2025-01-01T00:00:00Z","Action":"fail","Package":"example.com/app/store","Elapsed":0,"FailedBuild":"example.com/app/store [example.com/app/store.test]"}
+{"Time":"2025-01-01T00:00:00Z","Action":"start","Package":"example.com/app/ok"}
+{"Time":"2025-01-01T00:00:00Z","Action":"pass","Package":"example.com/app/ok","Elapsed":0.01}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(testInput))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.BuildDiagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %+v", result.BuildDiagnostics)
+	}
+	diagnostic := result.BuildDiagnostics[0]
+	if diagnostic.Package != "example.com/app/store" || diagnostic.Line != 82 || len(diagnostic.Details) != 2 {
+		t.Errorf("Unexpected diagnostic: %+v", diagnostic)
+	}
+	if result.FailedTests != 1 || result.TestDetails["BuildError"] == nil {
+		t.Errorf("Expected a BuildError failure like the text parser, got %d failures", result.FailedTests)
+	}
+	if store, _ := result.LookupPackage("example.com/app/store"); store.Status != PackageBuildFailed {
+		t.Errorf("Expected build_failed package status, got %+v", store)
+	}
+	if len(result.Packages) != 2 {
+		t.Errorf("Expected build events not to add packages, got %v", result.Packages)
+	}
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DiffLine 差异中的一行
+type DiffLine struct {
+	Op   string `json:"op"`             // "removed"、"added" 或 "context"
+	Side string `json:"side,omitempty"` // 该行属于期望值(want)还是实际值(got)，context 行为空
+	Text string `json:"text"`
+}
+
+// DiffHunk 差异块
+type DiffHunk struct {
+	Header string     `json:"header,omitempty"` // 统一格式中的 @@ -a,b +c,d @@
+	Lines  []DiffLine `json:"lines"`
+}
+
+// DiffBlock 测试输出中的一段差异，Removed/Added 标明 "-" 与 "+" 行分别对应 want 还是 got
+type DiffBlock struct {
+	Source      string     `json:"source"` // "cmp" 或 "testify"
+	Description string     `json:"description,omitempty"`
+	Removed     string     `json:"removed"`
+	Added       string     `json:"added"`
+	Hunks       []DiffHunk `json:"hunks"`
+}
+
+// cmp.Diff 的惯用说明，如 "Parse() mismatch (-want +got):"
+var diffOrientationPattern = regexp.MustCompile(`\(-(\w+)\s*,?\s*\+(\w+)\)`)
+
+// normalizeDiffSide 将 expected/actual 等说法统一为 want/got
+func normalizeDiffSide(label string) string {
+	switch strings.ToLower(label) {
+	case "want", "wanted", "expected", "expect", "exp":
+		return "want"
+	case "got", "actual", "have", "act":
+		return "got"
+	}
+	return strings.ToLower(label)
+}
+
+// newDiffLine 根据 "-"/"+" 前缀及方向构造差异行
+func newDiffLine(prefix rune, text, removed, added string) DiffLine {
+	switch prefix {
+	case '-':
+		return DiffLine{Op: "removed", Side: removed, Text: text}
+	case '+':
+		return DiffLine{Op: "added", Side: added, Text: text}
+	}
+	return DiffLine{Op: "context", Text: text}
+}
+
+// trimCmpSpace 去掉一个前导空格；cmp.Diff 会随机使用 U+00A0 代替前缀中的空格
+func trimCmpSpace(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == ' ' || r == '\u00a0' {
+		return s[size:]
+	}
+	return s
+}
+
+// parseDiffs 提取测试输出中的 cmp.Diff 差异以及 testify 断言中的 Diff
+func parseDiffs(output string, assertions []Assertion) []DiffBlock {
+	blocks := parseCmpDiffs(output)
+	for _, assertion := range assertions {
+		if assertion.Diff == "" {
+			continue
+		}
+		blocks = append(blocks, parseUnifiedDiff(assertion.Diff))
+	}
+	return blocks
+}
+
+// parseCmpDiffs 查找带有 (-want +got) 说明的日志行，其后缩进更深的续行即为 cmp.Diff 输出
+func parseCmpDiffs(output string) []DiffBlock {
+	var blocks []DiffBlock
+	lines := strings.Split(output, "\n")
+	
+	for i := 0; i < len(lines); i++ {
+		header := strings.TrimRight(lines[i], "\r")
+		matches := diffOrientationPattern.FindStringSubmatch(header)
+		if matches == nil {
+			continue
+		}
+		
+		block := DiffBlock{
+			Source:      "cmp",
+			Description: strings.TrimSpace(header),
+			Removed:     normalizeDiffSide(matches[1]),
+			Added:       normalizeDiffSide(matches[2]),
+		}
+		hunk := DiffHunk{Lines: make([]DiffLine, 0)}
+		
+		// t.Log 的续行比首行多缩进 4 个空格
+		base := len(header) - len(strings.TrimLeft(header, " ")) + 4
+		for i+1 < len(lines) {
+			line := strings.TrimRight(lines[i+1], "\r")
+			if len(line)-len(strings.TrimLeft(line, " ")) < base || len(line) == base {
+				break
+			}
+			i++
+			content := line[base:]
+			// 前缀按 rune 解码，U+00A0 与空格一样视为 context
+			prefix, size := utf8.DecodeRuneInString(content)
+			hunk.Lines = append(hunk.Lines, newDiffLine(prefix, trimCmpSpace(content[size:]), block.Removed, block.Added))
+		}
+		
+		if len(hunk.Lines) > 0 {
+			block.Hunks = []DiffHunk{hunk}
+			blocks = append(blocks, block)
+		}
+	}
+	
+	return blocks
+}
+
+// parseUnifiedDiff 解析 testify 的统一格式差异（--- Expected / +++ Actual / @@ ... @@）
+func parseUnifiedDiff(diff string) DiffBlock {
+	block := DiffBlock{
+		Source:  "testify",
+		Removed: "want",
+		Added:   "got",
+		Hunks:   make([]DiffHunk, 0),
+	}
+	var hunk *DiffHunk
+	
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			block.Removed = normalizeDiffSide(strings.TrimSpace(line[4:]))
+		case strings.HasPrefix(line, "+++ "):
+			block.Added = normalizeDiffSide(strings.TrimSpace(line[4:]))
+		case strings.HasPrefix(line, "@@"):
+			block.Hunks = append(block.Hunks, DiffHunk{Header: line, Lines: make([]DiffLine, 0)})
+			hunk = &block.Hunks[len(block.Hunks)-1]
+		case hunk != nil && line != "":
+			hunk.Lines = append(hunk.Lines, newDiffLine(rune(line[0]), line[1:], block.Removed, block.Added))
+		}
+	}
+	
+	return block
+}
@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestParseCmpDiffs 测试解析 cmp.Diff 输出
+func TestParseCmpDiffs(t *testing.T) {
+	// Arrange
+	output := "=== RUN   TestLoad\n" +
+		"    load_test.go:20: Load() mismatch (-want +got):\n" +
+		"          config.Config{\n" +
+		"          \tName: \"api\",\n" +
+		"        - \tPort: 8080,\n" +
+		"        + \tPort: 9090,\n" +
+		"          }\n" +
+		"    load_test.go:25: done\n"
+
+	// Act
+	blocks := parseCmpDiffs(output)
+
+	// Assert
+	if len(blocks) != 1 {
+		t.Fatalf("Expected 1 diff block, got %d", len(blocks))
+	}
+	block := blocks[0]
+	if block.Source != "cmp" || block.Removed != "want" || block.Added != "got" {
+		t.Errorf("Unexpected block orientation: %+v", block)
+	}
+	if block.Description != "load_test.go:20: Load() mismatch (-want +got):" {
+		t.Errorf("Unexpected description: %q", block.Description)
+	}
+	lines := block.Hunks[0].Lines
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 diff lines, got %d: %+v", len(lines), lines)
+	}
+	if lines[2].Op != "removed" || lines[2].Side != "want" || lines[2].Text != "\tPort: 8080," {
+		t.Errorf("Unexpected removed line: %+v", lines[2])
+	}
+	if lines[3].Op != "added" || lines[3].Side != "got" || lines[3].Text != "\tPort: 9090," {
+		t.Errorf("Unexpected added line: %+v", lines[3])
+	}
+	if lines[0].Op != "context" || lines[0].Side != "" || lines[0].Text != "config.Config{" {
+		t.Errorf("Unexpected context line: %+v", lines[0])
+	}
+}
+
+// TestParseCmpDiffs_ReversedOrientation 测试 (-got +want) 方向
+func TestParseCmpDiffs_ReversedOrientation(t *testing.T) {
+	// Arrange
+	output := "    a_test.go:9: diff (-got +want):\n" +
+		"        - 1\n" +
+		"        + 2\n"
+
+	// Act
+	blocks := parseCmpDiffs(output)
+
+	// Assert
+	if len(blocks) != 1 {
+		t.Fatalf("Expected 1 diff block, got %d", len(blocks))
+	}
+	lines := blocks[0].Hunks[0].Lines
+	if lines[0].Side != "got" || lines[1].Side != "want" {
+		t.Errorf("Expected removed=got and added=want, got %+v", lines)
+	}
+}
+
+// TestParseCmpDiffs_NonBreakingSpace 测试 cmp.Diff 以 U+00A0 代替前缀中空格的输出
+func TestParseCmpDiffs_NonBreakingSpace(t *testing.T) {
+	// Arrange: cmp.Diff 随机选择的 U+00A0 前缀形式
+	output := "    load_test.go:20: Load() mismatch (-want +got):\n" +
+		"        \u00a0\u00a0config.Config{\n" +
+		"        -\u00a0\tPort: 8080,\n" +
+		"        +\u00a0\tPort: 9090,\n" +
+		"        \u00a0\u00a0}\n"
+
+	// Act
+	blocks := parseCmpDiffs(output)
+
+	// Assert
+	if len(blocks) != 1 {
+		t.Fatalf("Expected 1 diff block, got %d", len(blocks))
+	}
+	lines := blocks[0].Hunks[0].Lines
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 diff lines, got %d: %+v", len(lines), lines)
+	}
+	if lines[0].Op != "context" || lines[0].Text != "config.Config{" {
+		t.Errorf("Unexpected context line: %+v", lines[0])
+	}
+	if lines[1].Op != "removed" || lines[1].Side != "want" || lines[1].Text != "\tPort: 8080," {
+		t.Errorf("Unexpected removed line: %+v", lines[1])
+	}
+	if lines[2].Op != "added" || lines[2].Side != "got" || lines[2].Text != "\tPort: 9090," {
+		t.Errorf("Unexpected added line: %+v", lines[2])
+	}
+	for _, line := range lines {
+		if !utf8.ValidString(line.Text) {
+			t.Errorf("Expected valid UTF-8 text, got %q", line.Text)
+		}
+	}
+}
+
+// TestParseUnifiedDiff 测试解析 testify 统一格式差异
+func TestParseUnifiedDiff(t *testing.T) {
+	// Arrange
+	diff := "--- Expected\n+++ Actual\n@@ -1,3 +1,3 @@\n (string) (len=3) {\n-  \"a\"\n+  \"b\"\n }"
+
+	// Act
+	block := parseUnifiedDiff(diff)
+
+	// Assert
+	if block.Removed != "want" || block.Added != "got" {
+		t.Errorf("Expected want/got orientation, got %s/%s", block.Removed, block.Added)
+	}
+	if len(block.Hunks) != 1 || block.Hunks[0].Header != "@@ -1,3 +1,3 @@" {
+		t.Fatalf("Unexpected hunks: %+v", block.Hunks)
+	}
+	lines := block.Hunks[0].Lines
+	if len(lines) != 4 || lines[1].Op != "removed" || lines[1].Text != "  \"a\"" || lines[2].Side != "got" {
+		t.Errorf("Unexpected lines: %+v", lines)
+	}
+}
+
+// TestParseTestLog_Diffs 测试JSON格式中差异的提取
+func TestParseTestLog_Diffs(t *testing.T) {
+	// Arrange
+	testInput := `{"Action":"run","Package":"example.com/app","Test":"TestLoad"}
+{"Action":"output","Package":"example.com/app","Test":"TestLoad","Output":"    load_test.go:20: mismatch (-expected +actual):\n"}
+{"Action":"output","Package":"example.com/app","Test":"TestLoad","Output":"        - \"x\"\n"}
+{"Action":"output","Package":"example.com/app","Test":"TestLoad","Output":"        + \"y\"\n"}
+{"Action":"fail","Package":"example.com/app","Test":"TestLoad","Elapsed":0}`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/app.TestLoad"]
+	if detail == nil || len(detail.Diffs) != 1 {
+		t.Fatalf("Expected 1 diff, got %+v", detail)
+	}
+	if lines := detail.Diffs[0].Hunks[0].Lines; len(lines) != 2 || lines[0].Side != "want" || lines[0].Text != `"x"` {
+		t.Errorf("Unexpected diff lines: %+v", lines)
+	}
+}
+
+// TestParseTestTextLog_TestifyDiff 测试 testify 断言中的差异
+func TestParseTestTextLog_TestifyDiff(t *testing.T) {
+	// Arrange
+	testInput := testifyEqualOutput + "--- FAIL: TestUpload (0.00s)\nFAIL\texample.com/app\t0.010s\n"
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/app.TestUpload"]
+	if detail == nil || len(detail.Diffs) != 1 || detail.Diffs[0].Source != "testify" {
+		t.Fatalf("Expected 1 testify diff, got %+v", detail)
+	}
+	lines := detail.Diffs[0].Hunks[0].Lines
+	if len(lines) != 2 || lines[0].Text != "done" || lines[0].Side != "want" || lines[1].Text != "pending" || lines[1].Side != "got" {
+		t.Errorf("Unexpected diff lines: %+v", lines)
+	}
+}
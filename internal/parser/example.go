@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExampleOutput 失败的 Example 函数的实际输出、期望输出及逐行差异（-want +got）
+type ExampleOutput struct {
+	Got  string     `json:"got"`
+	Want string     `json:"want"`
+	Diff []DiffLine `json:"diff"` // 输出过大时为空，只保留 Got/Want
+}
+
+// isExampleTerminator 判断一行是否结束 Example 的 want: 块
+func isExampleTerminator(trimmed string) bool {
+	return trimmed == "FAIL" || trimmed == "PASS" ||
+		strings.HasPrefix(trimmed, "--- ") ||
+		strings.HasPrefix(trimmed, "=== ") ||
+		strings.HasPrefix(trimmed, "exit status ") ||
+		strings.HasPrefix(trimmed, "FAIL\t") ||
+		strings.HasPrefix(trimmed, "ok  \t")
+}
+
+// parseExample 解析 Example 函数失败时打印的 got:/want: 块，没有时返回 nil
+func parseExample(output string) *ExampleOutput {
+	lines := strings.Split(output, "\n")
+	gotStart, wantStart := -1, -1
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "got:" && gotStart < 0 {
+			gotStart = i + 1
+		} else if trimmed == "want:" && gotStart >= 0 && wantStart < 0 {
+			wantStart = i + 1
+		}
+	}
+	if gotStart < 0 || wantStart < 0 {
+		return nil
+	}
+	
+	got := trimTrailingEmpty(lines[gotStart : wantStart-1])
+	wantEnd := wantStart
+	for wantEnd < len(lines) && !isExampleTerminator(strings.TrimSpace(lines[wantEnd])) {
+		wantEnd++
+	}
+	want := trimTrailingEmpty(lines[wantStart:wantEnd])
+	
+	return &ExampleOutput{
+		Got:  strings.Join(got, "\n"),
+		Want: strings.Join(want, "\n"),
+		Diff: lineDiff(want, got),
+	}
+}
+
+// trimTrailingEmpty 去掉末尾的空行
+func trimTrailingEmpty(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// maxLineDiffCells lineDiff 的最长公共子序列表最多的单元数，超出时不计算差异
+const maxLineDiffCells = 1 << 20
+
+// lineDiff 基于最长公共子序列计算 want 到 got 的逐行差异；
+// 去掉相同的首尾行后表格仍超过 maxLineDiffCells 时返回 nil，只保留 got/want 原文
+func lineDiff(want, got []string) []DiffLine {
+	prefix := 0
+	for prefix < len(want) && prefix < len(got) && want[prefix] == got[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(want)-prefix && suffix < len(got)-prefix && want[len(want)-1-suffix] == got[len(got)-1-suffix] {
+		suffix++
+	}
+	wantMiddle, gotMiddle := want[prefix:len(want)-suffix], got[prefix:len(got)-suffix]
+	if (len(wantMiddle)+1)*(len(gotMiddle)+1) > maxLineDiffCells {
+		return nil
+	}
+	
+	diff := make([]DiffLine, 0, len(want)+len(got))
+	for _, line := range want[:prefix] {
+		diff = append(diff, newDiffLine(' ', line, "want", "got"))
+	}
+	diff = append(diff, middleDiff(wantMiddle, gotMiddle)...)
+	for _, line := range want[len(want)-suffix:] {
+		diff = append(diff, newDiffLine(' ', line, "want", "got"))
+	}
+	return diff
+}
+
+// middleDiff 对首尾不同的部分按最长公共子序列计算逐行差异
+func middleDiff(want, got []string) []DiffLine {
+	// lcs[i][j] 为 want[i:] 与 got[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(want)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(got)+1)
+	}
+	for i := len(want) - 1; i >= 0; i-- {
+		for j := len(got) - 1; j >= 0; j-- {
+			if want[i] == got[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	
+	diff := make([]DiffLine, 0, len(want)+len(got))
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		switch {
+		case i < len(want) && j < len(got) && want[i] == got[j]:
+			diff = append(diff, newDiffLine(' ', want[i], "want", "got"))
+			i++
+			j++
+		case j >= len(got) || (i < len(want) && lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, newDiffLine('-', want[i], "want", "got"))
+			i++
+		default:
+			diff = append(diff, newDiffLine('+', got[j], "want", "got"))
+			j++
+		}
+	}
+	return diff
+}
+
+// formatExample 将 Example 的实际输出和期望输出格式化为错误信息
+func formatExample(example *ExampleOutput) string {
+	return fmt.Sprintf("got:\n%s\nwant:\n%s", example.Got, example.Want)
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_ExampleFailure 测试 Example 函数失败时完整捕获 got:/want: 块并生成逐行差异
+func TestParseTestTextLog_ExampleFailure(t *testing.T) {
+	// Arrange
+	log := `=== RUN   ExampleGreet
+--- FAIL: ExampleGreet (0.00s)
+got:
+Hello, World
+from go
+want:
+Hello, world
+from go
+=== RUN   ExampleOther
+--- PASS: ExampleOther (0.00s)
+FAIL
+FAIL	example.com/greet	0.002s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/greet.ExampleGreet"]
+	if detail == nil || detail.Kind != KindExample {
+		t.Fatalf("Expected example kind, got %+v", detail)
+	}
+	example := detail.Example
+	if example == nil {
+		t.Fatal("Expected example output, got nil")
+	}
+	if example.Got != "Hello, World\nfrom go" || example.Want != "Hello, world\nfrom go" {
+		t.Errorf("Unexpected got/want: %q / %q", example.Got, example.Want)
+	}
+	expected := []DiffLine{
+		{Op: "removed", Side: "want", Text: "Hello, world"},
+		{Op: "added", Side: "got", Text: "Hello, World"},
+		{Op: "context", Text: "from go"},
+	}
+	if len(example.Diff) != len(expected) {
+		t.Fatalf("Expected %d diff lines, got %+v", len(expected), example.Diff)
+	}
+	for i, line := range expected {
+		if example.Diff[i] != line {
+			t.Errorf("Diff line %d: expected %+v, got %+v", i, line, example.Diff[i])
+		}
+	}
+	if detail.Error != "got:\nHello, World\nfrom go\nwant:\nHello, world\nfrom go" {
+		t.Errorf("Unexpected error: %q", detail.Error)
+	}
+	if other := result.TestDetails["example.com/greet.ExampleOther"]; other.Kind != KindExample || other.Example != nil {
+		t.Errorf("Expected passing example without output block, got %+v", other)
+	}
+}
+
+// TestParseTestLog_ExampleFailure 测试 JSON 日志中 fail 事件之后到达的 got:/want: 块
+func TestParseTestLog_ExampleFailure(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"ExampleSum"}
+{"Action":"output","Package":"example","Test":"ExampleSum","Output":"--- FAIL: ExampleSum (0.00s)\n"}
+{"Action":"fail","Package":"example","Test":"ExampleSum","Elapsed":0}
+{"Action":"output","Package":"example","Output":"got:\n"}
+{"Action":"output","Package":"example","Output":"3\n"}
+{"Action":"output","Package":"example","Output":"want:\n"}
+{"Action":"output","Package":"example","Output":"4\n"}
+{"Action":"output","Package":"example","Output":"FAIL\n"}
+{"Action":"output","Package":"example","Output":"FAIL\texample\t0.010s\n"}
+{"Action":"fail","Package":"example","Elapsed":0.01}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.ExampleSum"]
+	if strings.Contains(detail.Output, "FAIL\texample") || strings.HasSuffix(detail.Output, "FAIL\n") {
+		t.Errorf("Expected package output after the got/want block not to be attached, got %q", detail.Output)
+	}
+	if detail.Status != "fail" || detail.Example == nil {
+		t.Fatalf("Expected failed example with output, got %+v", detail)
+	}
+	if detail.Example.Got != "3" || detail.Example.Want != "4" {
+		t.Errorf("Unexpected got/want: %q / %q", detail.Example.Got, detail.Example.Want)
+	}
+}
+
+// TestLineDiff 测试逐行差异中的新增、删除和公共行
+func TestLineDiff(t *testing.T) {
+	// Act
+	diff := lineDiff([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+
+	// Assert
+	ops := make([]string, 0, len(diff))
+	for _, line := range diff {
+		ops = append(ops, line.Op+":"+line.Text)
+	}
+	expected := "context:a,removed:b,context:c,added:d"
+	if strings.Join(ops, ",") != expected {
+		t.Errorf("Expected %s, got %s", expected, strings.Join(ops, ","))
+	}
+}
+
+// TestLineDiff_LargeOutput 测试首尾相同的大输出只对中间部分计算差异，中间部分过大时不计算差异
+func TestLineDiff_LargeOutput(t *testing.T) {
+	// Arrange
+	common := make([]string, 5000)
+	for i := range common {
+		common[i] = fmt.Sprintf("line %d", i)
+	}
+	want := append(append([]string{}, common...), "tail")
+	got := append(append([]string{}, common...), "other")
+	wantLarge := make([]string, 2000)
+	gotLarge := make([]string, 2000)
+	for i := range wantLarge {
+		wantLarge[i] = fmt.Sprintf("want %d", i)
+		gotLarge[i] = fmt.Sprintf("got %d", i)
+	}
+
+	// Act
+	diff := lineDiff(want, got)
+	large := lineDiff(wantLarge, gotLarge)
+
+	// Assert
+	if len(diff) != 5002 || diff[5000].Op != "removed" || diff[5001].Op != "added" {
+		t.Errorf("Expected common lines as context and one changed line, got %d lines", len(diff))
+	}
+	if large != nil {
+		t.Errorf("Expected no diff for a %dx%d table, got %d lines", len(wantLarge), len(gotLarge), len(large))
+	}
+}
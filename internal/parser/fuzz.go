@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/allanpk716/go_test_reader/internal/gomod"
+)
+
+// FuzzInfo 模糊测试的执行统计和失败输入
+type FuzzInfo struct {
+	Elapsed          string `json:"elapsed,omitempty"` // 最后一次进度报告的耗时
+	Execs            int64  `json:"execs"`
+	ExecsPerSec      int64  `json:"execs_per_sec"`
+	NewInteresting   int    `json:"new_interesting"`
+	TotalInteresting int    `json:"total_interesting"`
+	Workers          int    `json:"workers,omitempty"`
+	FailingInput     string `json:"failing_input,omitempty"` // 相对包目录的语料文件路径，如 testdata/fuzz/FuzzX/abc123
+	RerunCommand     string `json:"rerun_command,omitempty"`
+
+	// 提供模块根目录时加载的失败语料内容
+	FailingInputContent string   `json:"failing_input_content,omitempty"`
+	FailingInputValues  []string `json:"failing_input_values,omitempty"`
+}
+
+var (
+	// fuzz: elapsed: 3s, execs: 325017 (108336/sec), new interesting: 11 (total: 202)
+	fuzzProgressPattern = regexp.MustCompile(`^fuzz: elapsed: (\S+), execs: (\d+) \((\d+)/sec\), new interesting: (\d+) \(total: (\d+)\)`)
+	// fuzz: elapsed: 0s, gathering baseline coverage: 192/192 completed, now fuzzing with 8 workers
+	fuzzWorkersPattern = regexp.MustCompile(`now fuzzing with (\d+) workers`)
+	// Failing input written to testdata/fuzz/FuzzParse/771e938e4458e983
+	fuzzFailingInputPattern = regexp.MustCompile(`^Failing input written to (\S+)$`)
+)
+
+// isFuzzLine 判断一行是否为模糊测试引擎的进度输出
+func isFuzzLine(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "fuzz: ")
+}
+
+// parseFuzz 从模糊测试的输出中解析执行统计和失败输入，没有相关输出时返回 nil
+func parseFuzz(output string) *FuzzInfo {
+	var info *FuzzInfo
+	ensure := func() *FuzzInfo {
+		if info == nil {
+			info = &FuzzInfo{}
+		}
+		return info
+	}
+	
+	expectRerun := false
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		
+		if expectRerun {
+			ensure().RerunCommand = trimmed
+			expectRerun = false
+			continue
+		}
+		if matches := fuzzProgressPattern.FindStringSubmatch(trimmed); matches != nil {
+			fuzz := ensure()
+			fuzz.Elapsed = matches[1]
+			fuzz.Execs, _ = strconv.ParseInt(matches[2], 10, 64)
+			fuzz.ExecsPerSec, _ = strconv.ParseInt(matches[3], 10, 64)
+			fuzz.NewInteresting, _ = strconv.Atoi(matches[4])
+			fuzz.TotalInteresting, _ = strconv.Atoi(matches[5])
+			continue
+		}
+		if isFuzzLine(trimmed) {
+			if matches := fuzzWorkersPattern.FindStringSubmatch(trimmed); matches != nil {
+				ensure().Workers, _ = strconv.Atoi(matches[1])
+			}
+			continue
+		}
+		if matches := fuzzFailingInputPattern.FindStringSubmatch(trimmed); matches != nil {
+			ensure().FailingInput = matches[1]
+			continue
+		}
+		if trimmed == "To re-run:" {
+			expectRerun = true
+		}
+	}
+	
+	return info
+}
+
+// LoadFailingInput 从模块根目录加载失败语料文件的内容。语料路径相对于包目录，
+// 包目录由 go.mod 中的模块路径推算，找不到时再尝试相对模块根目录。
+// 语料路径和包名都来自日志内容，语料路径清理后必须位于 testdata/fuzz 目录下，包名不能含有 ..，否则拒绝读取
+func (f *FuzzInfo) LoadFailingInput(moduleRoot, pkg string) error {
+	if f.FailingInput == "" {
+		return fmt.Errorf("no failing input recorded")
+	}
+	for _, element := range strings.FieldsFunc(pkg, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if element == ".." {
+			return fmt.Errorf("package %s escapes the module root", pkg)
+		}
+	}
+	pkg = path.Clean(pkg)
+	input := filepath.Clean(filepath.FromSlash(f.FailingInput))
+	if filepath.IsAbs(input) || !strings.HasPrefix(input, filepath.Join("testdata", "fuzz")+string(filepath.Separator)) {
+		return fmt.Errorf("failing input %s is outside testdata/fuzz", f.FailingInput)
+	}
+	
+	candidates := make([]string, 0, 2)
+	if modulePath, err := gomod.ModulePath(moduleRoot); err == nil {
+		if rel, ok := packageRelativeDir(modulePath, pkg); ok {
+			candidates = append(candidates, filepath.Join(moduleRoot, rel, input))
+		}
+	}
+	candidates = append(candidates, filepath.Join(moduleRoot, input))
+	
+	for _, path := range candidates {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		f.FailingInputContent = string(content)
+		f.FailingInputValues = parseCorpusValues(f.FailingInputContent)
+		return nil
+	}
+	return fmt.Errorf("failing input %s not found under %s", f.FailingInput, moduleRoot)
+}
+
+// packageRelativeDir 返回包相对模块根目录的路径
+func packageRelativeDir(modulePath, pkg string) (string, bool) {
+	if pkg == modulePath {
+		return ".", true
+	}
+	if strings.HasPrefix(pkg, modulePath+"/") {
+		return filepath.FromSlash(strings.TrimPrefix(pkg, modulePath+"/")), true
+	}
+	return "", false
+}
+
+// parseCorpusValues 解析 "go test fuzz v1" 语料文件中的参数值，每行一个
+func parseCorpusValues(content string) []string {
+	values := make([]string, 0)
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || (i == 0 && strings.HasPrefix(line, "go test fuzz")) {
+			continue
+		}
+		values = append(values, line)
+	}
+	return values
+}
@@ -0,0 +1,221 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const fuzzTextLog = `=== RUN   FuzzParse
+fuzz: elapsed: 0s, gathering baseline coverage: 0/192 completed
+fuzz: elapsed: 0s, gathering baseline coverage: 192/192 completed, now fuzzing with 8 workers
+fuzz: elapsed: 3s, execs: 325017 (108336/sec), new interesting: 11 (total: 202)
+fuzz: elapsed: 4s, execs: 401234 (95000/sec), new interesting: 12 (total: 203)
+--- FAIL: FuzzParse (4.12s)
+    --- FAIL: FuzzParse (0.00s)
+        parse_test.go:25: unexpected error for input "\x00"
+    
+    Failing input written to testdata/fuzz/FuzzParse/771e938e4458e983
+    To re-run:
+    go test -run=FuzzParse/771e938e4458e983
+FAIL
+exit status 1
+FAIL	example.com/app/parse	4.134s`
+
+// TestParseTestTextLog_Fuzz 测试模糊测试的执行统计和失败输入解析，缩进的同名 --- FAIL 不重复计数
+func TestParseTestTextLog_Fuzz(t *testing.T) {
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(fuzzTextLog))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.FailedTests != 1 || len(result.FailedTestNames) != 1 {
+		t.Errorf("Expected 1 failed test, got %d %v", result.FailedTests, result.FailedTestNames)
+	}
+	detail := result.TestDetails["example.com/app/parse.FuzzParse"]
+	if detail == nil || detail.Kind != KindFuzz {
+		t.Fatalf("Expected fuzz test, got %+v", detail)
+	}
+	fuzz := detail.Fuzz
+	if fuzz == nil {
+		t.Fatal("Expected fuzz info, got nil")
+	}
+	if fuzz.Execs != 401234 || fuzz.ExecsPerSec != 95000 || fuzz.NewInteresting != 12 || fuzz.TotalInteresting != 203 || fuzz.Elapsed != "4s" {
+		t.Errorf("Unexpected fuzz stats: %+v", fuzz)
+	}
+	if fuzz.Workers != 8 {
+		t.Errorf("Expected 8 workers, got %d", fuzz.Workers)
+	}
+	if fuzz.FailingInput != "testdata/fuzz/FuzzParse/771e938e4458e983" {
+		t.Errorf("Unexpected failing input: %q", fuzz.FailingInput)
+	}
+	if fuzz.RerunCommand != "go test -run=FuzzParse/771e938e4458e983" {
+		t.Errorf("Unexpected rerun command: %q", fuzz.RerunCommand)
+	}
+}
+
+// TestParseTestTextLog_FuzzWithoutVerbose 测试非 -v 模式下没有 === RUN 行的模糊测试进度输出
+func TestParseTestTextLog_FuzzWithoutVerbose(t *testing.T) {
+	// Arrange
+	log := `fuzz: elapsed: 3s, execs: 1000 (333/sec), new interesting: 2 (total: 10)
+--- FAIL: FuzzDecode (3.01s)
+    Failing input written to testdata/fuzz/FuzzDecode/abc123
+FAIL	example	3.020s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	fuzz := result.TestDetails["example.FuzzDecode"].Fuzz
+	if fuzz == nil || fuzz.Execs != 1000 || fuzz.FailingInput != "testdata/fuzz/FuzzDecode/abc123" {
+		t.Errorf("Unexpected fuzz info: %+v", fuzz)
+	}
+}
+
+// TestParseTestLog_FuzzDuplicateFail 测试 JSON 日志中模糊测试重复的 fail 事件不重复计数
+func TestParseTestLog_FuzzDuplicateFail(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"FuzzParse"}
+{"Action":"output","Package":"example","Test":"FuzzParse","Output":"fuzz: elapsed: 1s, execs: 500 (500/sec), new interesting: 1 (total: 5)\n"}
+{"Action":"output","Package":"example","Test":"FuzzParse","Output":"--- FAIL: FuzzParse (1.00s)\n"}
+{"Action":"output","Package":"example","Test":"FuzzParse","Output":"    --- FAIL: FuzzParse (0.00s)\n"}
+{"Action":"fail","Package":"example","Test":"FuzzParse","Elapsed":0}
+{"Action":"output","Package":"example","Test":"FuzzParse","Output":"    Failing input written to testdata/fuzz/FuzzParse/ff00\n"}
+{"Action":"fail","Package":"example","Test":"FuzzParse","Elapsed":1}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.FailedTests != 1 {
+		t.Errorf("Expected 1 failed test, got %d", result.FailedTests)
+	}
+	detail := result.TestDetails["example.FuzzParse"]
+	if detail.Kind != KindFuzz || detail.Fuzz == nil || detail.Fuzz.Execs != 500 {
+		t.Errorf("Unexpected fuzz detail: %+v", detail.Fuzz)
+	}
+}
+
+// TestFuzzInfo_LoadFailingInput 测试根据 go.mod 模块路径从包目录加载失败语料
+func TestFuzzInfo_LoadFailingInput(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	corpusDir := filepath.Join(root, "parse", "testdata", "fuzz", "FuzzParse")
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		t.Fatalf("Failed to create corpus dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n\ngo 1.23\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	corpus := "go test fuzz v1\nstring(\"\\x00\")\nint(7)\n"
+	if err := os.WriteFile(filepath.Join(corpusDir, "771e938e4458e983"), []byte(corpus), 0644); err != nil {
+		t.Fatalf("Failed to write corpus file: %v", err)
+	}
+	info := &FuzzInfo{FailingInput: "testdata/fuzz/FuzzParse/771e938e4458e983"}
+
+	// Act
+	err := info.LoadFailingInput(root, "example.com/app/parse")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.FailingInputContent != corpus {
+		t.Errorf("Unexpected corpus content: %q", info.FailingInputContent)
+	}
+	if len(info.FailingInputValues) != 2 || info.FailingInputValues[1] != "int(7)" {
+		t.Errorf("Unexpected corpus values: %v", info.FailingInputValues)
+	}
+}
+
+// TestFuzzInfo_LoadFailingInput_NotFound 测试语料文件不存在时返回错误
+func TestFuzzInfo_LoadFailingInput_NotFound(t *testing.T) {
+	// Arrange
+	info := &FuzzInfo{FailingInput: "testdata/fuzz/FuzzParse/missing"}
+
+	// Act
+	err := info.LoadFailingInput(t.TempDir(), "example.com/app")
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for missing corpus file")
+	}
+}
+
+// TestFuzzInfo_LoadFailingInput_OutsideCorpus 测试语料路径跳出 testdata/fuzz 目录时拒绝读取
+func TestFuzzInfo_LoadFailingInput_OutsideCorpus(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	moduleRoot := filepath.Join(root, "app")
+	if err := os.MkdirAll(filepath.Join(moduleRoot, "parse", "testdata", "fuzz"), 0755); err != nil {
+		t.Fatalf("Failed to create corpus dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleRoot, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "secret"), []byte("go test fuzz v1\nstring(\"secret\")\n"), 0644); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	inputs := []string{
+		"testdata/fuzz/../../../secret",
+		"../secret",
+		"testdata/../../secret",
+		filepath.Join(root, "secret"),
+	}
+
+	for _, input := range inputs {
+		info := &FuzzInfo{FailingInput: input}
+
+		// Act
+		err := info.LoadFailingInput(moduleRoot, "example.com/app/parse")
+
+		// Assert
+		if err == nil {
+			t.Errorf("Expected error for failing input %q", input)
+		}
+		if info.FailingInputContent != "" {
+			t.Errorf("Expected no content read for %q, got %q", input, info.FailingInputContent)
+		}
+	}
+}
+
+// TestFuzzInfo_LoadFailingInput_PackageOutsideModule 测试包名含有 .. 时拒绝读取
+func TestFuzzInfo_LoadFailingInput_PackageOutsideModule(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	moduleRoot := filepath.Join(root, "app")
+	if err := os.MkdirAll(moduleRoot, 0755); err != nil {
+		t.Fatalf("Failed to create module dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleRoot, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	corpusDir := filepath.Join(root, "secret", "testdata", "fuzz", "FuzzParse")
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		t.Fatalf("Failed to create corpus dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(corpusDir, "abc"), []byte("go test fuzz v1\nstring(\"secret\")\n"), 0644); err != nil {
+		t.Fatalf("Failed to write corpus file: %v", err)
+	}
+	info := &FuzzInfo{FailingInput: "testdata/fuzz/FuzzParse/abc"}
+
+	// Act
+	err := info.LoadFailingInput(moduleRoot, "example.com/app/../secret")
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for package escaping the module root")
+	}
+	if info.FailingInputContent != "" {
+		t.Errorf("Expected no content read, got %q", info.FailingInputContent)
+	}
+}
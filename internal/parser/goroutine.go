@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 全部协程阻塞时 runtime 报告的 fatal error
+const deadlockMessage = "all goroutines are asleep - deadlock!"
+
+// 协程状态中的阻塞时长，如 "5 minutes"、"1 minute"
+var waitMinutesPattern = regexp.MustCompile(`^(\d+) minutes?$`)
+
+// GoroutineGroup 栈相同且等待原因相同的一组协程
+type GoroutineGroup struct {
+	WaitReason     string       `json:"wait_reason"`
+	Count          int          `json:"count"`
+	IDs            []int        `json:"ids"`
+	MinWaitMinutes int          `json:"min_wait_minutes"`
+	MaxWaitMinutes int          `json:"max_wait_minutes"`
+	Frames         []StackFrame `json:"frames"`
+	CreatedBy      *StackFrame  `json:"created_by,omitempty"`
+	TestFrame      *StackFrame  `json:"test_frame,omitempty"` // 栈中第一个位于 _test.go 的帧，不在测试代码中时为 nil
+}
+
+// WaitReasonCount 按等待原因统计的协程数
+type WaitReasonCount struct {
+	WaitReason     string `json:"wait_reason"`
+	Count          int    `json:"count"`
+	MaxWaitMinutes int    `json:"max_wait_minutes"`
+}
+
+// GoroutineAnalysis 协程转储的分析结果，用于排查超时和死锁
+type GoroutineAnalysis struct {
+	Deadlock       bool              `json:"deadlock"`
+	Total          int               `json:"total"`
+	WaitReasons    []WaitReasonCount `json:"wait_reasons"`
+	Groups         []GoroutineGroup  `json:"groups"` // 位于测试代码中的分组排在最前，其余按协程数从多到少
+	TestCodeGroups int               `json:"test_code_groups"`
+}
+
+// parseGoroutineState 将 "chan receive, 5 minutes, locked to thread" 拆分为等待原因和阻塞分钟数
+func parseGoroutineState(state string) (string, int) {
+	parts := strings.Split(state, ", ")
+	minutes := 0
+	for _, part := range parts[1:] {
+		if matches := waitMinutesPattern.FindStringSubmatch(part); matches != nil {
+			minutes, _ = strconv.Atoi(matches[1])
+		}
+	}
+	return parts[0], minutes
+}
+
+// stackKey 返回协程栈的标识，栈帧和创建位置都相同的协程视为同一个栈
+func stackKey(goroutine Goroutine) string {
+	var builder strings.Builder
+	builder.WriteString(goroutine.WaitReason)
+	for _, frame := range goroutine.Frames {
+		fmt.Fprintf(&builder, "\n%s %s:%d", frame.Function, frame.File, frame.Line)
+	}
+	if goroutine.CreatedBy != nil {
+		fmt.Fprintf(&builder, "\ncreated by %s %s:%d", goroutine.CreatedBy.Function, goroutine.CreatedBy.File, goroutine.CreatedBy.Line)
+	}
+	return builder.String()
+}
+
+// testFrame 返回栈中第一个位于 _test.go 文件中的被测代码帧
+func testFrame(frames []StackFrame) *StackFrame {
+	for i := range frames {
+		if frames[i].IsUser && strings.HasSuffix(frames[i].File, "_test.go") {
+			frame := frames[i]
+			return &frame
+		}
+	}
+	return nil
+}
+
+// AnalyzeGoroutines 按栈和等待原因对协程分组，统计各等待原因的协程数和阻塞时长
+func AnalyzeGoroutines(goroutines []Goroutine) *GoroutineAnalysis {
+	analysis := &GoroutineAnalysis{
+		Total:       len(goroutines),
+		WaitReasons: make([]WaitReasonCount, 0),
+		Groups:      make([]GoroutineGroup, 0),
+	}
+	
+	groupIndex := make(map[string]int)
+	reasonIndex := make(map[string]int)
+	for _, goroutine := range goroutines {
+		key := stackKey(goroutine)
+		i, exists := groupIndex[key]
+		if !exists {
+			i = len(analysis.Groups)
+			groupIndex[key] = i
+			analysis.Groups = append(analysis.Groups, GoroutineGroup{
+				WaitReason:     goroutine.WaitReason,
+				IDs:            make([]int, 0),
+				MinWaitMinutes: goroutine.WaitMinutes,
+				Frames:         goroutine.Frames,
+				CreatedBy:      goroutine.CreatedBy,
+				TestFrame:      testFrame(goroutine.Frames),
+			})
+		}
+		group := &analysis.Groups[i]
+		group.Count++
+		group.IDs = append(group.IDs, goroutine.ID)
+		group.MinWaitMinutes = min(group.MinWaitMinutes, goroutine.WaitMinutes)
+		group.MaxWaitMinutes = max(group.MaxWaitMinutes, goroutine.WaitMinutes)
+	
+		j, exists := reasonIndex[goroutine.WaitReason]
+		if !exists {
+			j = len(analysis.WaitReasons)
+			reasonIndex[goroutine.WaitReason] = j
+			analysis.WaitReasons = append(analysis.WaitReasons, WaitReasonCount{WaitReason: goroutine.WaitReason})
+		}
+		reason := &analysis.WaitReasons[j]
+		reason.Count++
+		reason.MaxWaitMinutes = max(reason.MaxWaitMinutes, goroutine.WaitMinutes)
+	}
+	
+	sort.SliceStable(analysis.Groups, func(i, j int) bool {
+		a, b := analysis.Groups[i], analysis.Groups[j]
+		if (a.TestFrame != nil) != (b.TestFrame != nil) {
+			return a.TestFrame != nil
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.MaxWaitMinutes > b.MaxWaitMinutes
+	})
+	sort.SliceStable(analysis.WaitReasons, func(i, j int) bool {
+		return analysis.WaitReasons[i].Count > analysis.WaitReasons[j].Count
+	})
+	for _, group := range analysis.Groups {
+		if group.TestFrame != nil {
+			analysis.TestCodeGroups++
+		}
+	}
+	return analysis
+}
+
+// AnalyzeGoroutines 分析测试挂起时的协程转储：超时测试的转储或死锁的 fatal error，
+// 测试没有挂起或输出中没有协程转储时返回 nil
+func (d *TestDetail) AnalyzeGoroutines() *GoroutineAnalysis {
+	if d.Panic == nil || len(d.Panic.Goroutines) == 0 {
+		return nil
+	}
+	deadlock := d.Panic.Kind == "fatal error" && d.Panic.Value == deadlockMessage
+	if d.Status != "timeout" && !deadlock {
+		return nil
+	}
+	analysis := AnalyzeGoroutines(d.Panic.Goroutines)
+	analysis.Deadlock = deadlock
+	return analysis
+}
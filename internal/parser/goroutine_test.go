@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const hangTextLog = `=== RUN   TestPool
+panic: test timed out after 10m0s
+	running tests:
+		TestPool (10m0s)
+
+goroutine 30 [running]:
+testing.(*M).startAlarm.func1()
+	/usr/local/go/src/testing/testing.go:2259 +0x3b9
+created by time.goFunc
+	/usr/local/go/src/time/sleep.go:176 +0x2d
+
+goroutine 1 [chan receive, 10 minutes]:
+testing.(*T).Run(0xc000007860, {0x5c3a8e, 0x8}, 0x5ce1d8)
+	/usr/local/go/src/testing/testing.go:1649 +0x3c8
+main.main()
+	_testmain.go:47 +0x1aa
+
+goroutine 7 [semacquire, 10 minutes]:
+sync.runtime_Semacquire(0xc00001c0b8)
+	/usr/local/go/src/runtime/sema.go:62 +0x25
+sync.(*WaitGroup).Wait(0xc00001c0b0)
+	/usr/local/go/src/sync/waitgroup.go:116 +0x48
+example.com/app/pool.TestPool(0xc000007a00)
+	/home/dev/app/pool/pool_test.go:25 +0x9a
+testing.tRunner(0xc000007a00, 0x5ce1d8)
+	/usr/local/go/src/testing/testing.go:1595 +0xff
+created by testing.(*T).Run in goroutine 1
+	/usr/local/go/src/testing/testing.go:1648 +0x3ad
+
+goroutine 8 [chan receive, 10 minutes]:
+example.com/app/pool.(*Pool).worker(0xc0000a4000)
+	/home/dev/app/pool/pool.go:40 +0x65
+created by example.com/app/pool.New in goroutine 7
+	/home/dev/app/pool/pool.go:21 +0x85
+
+goroutine 9 [chan receive, 9 minutes]:
+example.com/app/pool.(*Pool).worker(0xc0000a4000)
+	/home/dev/app/pool/pool.go:40 +0x65
+created by example.com/app/pool.New in goroutine 7
+	/home/dev/app/pool/pool.go:21 +0x85
+
+goroutine 10 [chan receive]:
+example.com/app/pool.(*Pool).worker(0xc0000a4000)
+	/home/dev/app/pool/pool.go:40 +0x65
+created by example.com/app/pool.New in goroutine 7
+	/home/dev/app/pool/pool.go:21 +0x85
+exit status 2
+FAIL	example.com/app/pool	600.012s`
+
+// TestParseGoroutineState 测试协程状态拆分为等待原因和阻塞分钟数
+func TestParseGoroutineState(t *testing.T) {
+	tests := []struct {
+		state   string
+		reason  string
+		minutes int
+	}{
+		{"running", "running", 0},
+		{"chan receive, 5 minutes", "chan receive", 5},
+		{"select, 1 minute", "select", 1},
+		{"IO wait, 12 minutes, locked to thread", "IO wait", 12},
+		{"select (no cases)", "select (no cases)", 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			// Act
+			reason, minutes := parseGoroutineState(tt.state)
+			
+			// Assert
+			if reason != tt.reason || minutes != tt.minutes {
+				t.Errorf("Expected (%q, %d), got (%q, %d)", tt.reason, tt.minutes, reason, minutes)
+			}
+		})
+	}
+}
+
+// TestAnalyzeGoroutines_Timeout 测试超时转储按栈和等待原因分组，测试代码中的分组排在最前
+func TestAnalyzeGoroutines_Timeout(t *testing.T) {
+	// Arrange
+	result, err := ParseTestTextLog(strings.NewReader(hangTextLog))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/app/pool.TestPool"]
+	
+	// Act
+	analysis := detail.AnalyzeGoroutines()
+	
+	// Assert
+	if analysis == nil {
+		t.Fatal("Expected goroutine analysis for timed out test")
+	}
+	if analysis.Deadlock || analysis.Total != 6 || len(analysis.Groups) != 4 || analysis.TestCodeGroups != 1 {
+		t.Errorf("Unexpected analysis: deadlock=%v total=%d groups=%d test_code_groups=%d", analysis.Deadlock, analysis.Total, len(analysis.Groups), analysis.TestCodeGroups)
+	}
+	first := analysis.Groups[0]
+	if first.WaitReason != "semacquire" || first.TestFrame == nil || first.TestFrame.File != "/home/dev/app/pool/pool_test.go" || first.TestFrame.Line != 25 {
+		t.Errorf("Expected test goroutine group first, got %+v", first)
+	}
+	workers := analysis.Groups[1]
+	if workers.Count != 3 || workers.MinWaitMinutes != 0 || workers.MaxWaitMinutes != 10 || len(workers.IDs) != 3 || workers.TestFrame != nil {
+		t.Errorf("Unexpected worker group: %+v", workers)
+	}
+	if reason := analysis.WaitReasons[0]; reason.WaitReason != "chan receive" || reason.Count != 4 || reason.MaxWaitMinutes != 10 {
+		t.Errorf("Unexpected top wait reason: %+v", reason)
+	}
+}
+
+// TestAnalyzeGoroutines_Deadlock 测试死锁的 fatal error 转储，测试未完成也能分析
+func TestAnalyzeGoroutines_Deadlock(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestLock"}
+{"Action":"output","Package":"example","Test":"TestLock","Output":"fatal error: all goroutines are asleep - deadlock!\n"}
+{"Action":"output","Package":"example","Test":"TestLock","Output":"\n"}
+{"Action":"output","Package":"example","Test":"TestLock","Output":"goroutine 6 [sync.Mutex.Lock]:\n"}
+{"Action":"output","Package":"example","Test":"TestLock","Output":"example.TestLock(0xc000007a00)\n"}
+{"Action":"output","Package":"example","Test":"TestLock","Output":"\t/src/example/lock_test.go:12 +0x5d\n"}
+{"Action":"output","Package":"example","Output":"exit status 2\n"}
+{"Action":"fail","Package":"example","Elapsed":0.01}`
+	result, err := ParseTestLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.TestLock"]
+	
+	// Act
+	analysis := detail.AnalyzeGoroutines()
+	
+	// Assert
+	if detail.Status != "incomplete" {
+		t.Errorf("Expected deadlocked test to be incomplete, got %s", detail.Status)
+	}
+	if analysis == nil || !analysis.Deadlock || analysis.Total != 1 {
+		t.Fatalf("Expected deadlock analysis, got %+v", analysis)
+	}
+	if group := analysis.Groups[0]; group.WaitReason != "sync.Mutex.Lock" || group.TestFrame == nil || group.TestFrame.Line != 12 {
+		t.Errorf("Unexpected group: %+v", group)
+	}
+}
+
+// TestAnalyzeGoroutines_NotHung 测试普通 panic 不做协程分析
+func TestAnalyzeGoroutines_NotHung(t *testing.T) {
+	// Arrange
+	detail := &TestDetail{
+		Status: "fail",
+		Panic: &PanicInfo{
+			Kind:       "panic",
+			Value:      "boom",
+			Goroutines: []Goroutine{{ID: 7, State: "running", WaitReason: "running"}},
+		},
+	}
+	
+	// Act
+	analysis := detail.AnalyzeGoroutines()
+	
+	// Assert
+	if analysis != nil {
+		t.Errorf("Expected no analysis for ordinary panic, got %+v", analysis)
+	}
+}
@@ -0,0 +1,375 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// 默认限制：单行最多保留 1 MiB，每个测试的输出最多保留 1 MiB（开头和末尾各一半）
+const (
+	DefaultMaxLineBytes   = 1 << 20
+	DefaultMaxOutputBytes = 1 << 20
+)
+
+// OutputRetention 每个测试保留的输出上限，各字段为 0 表示不限制。
+// 超出上限时保留开头和末尾的输出，中间替换为截断标记；
+// 设置了行数窗口时开头保留 HeadLines 行、末尾保留 TailLines 行，
+// 设置了 MaxBytes 时开头和末尾各最多保留一半字节，超过一半的单行截断到剩余空间
+type OutputRetention struct {
+	HeadLines int `json:"head_lines"`
+	TailLines int `json:"tail_lines"`
+	MaxBytes  int `json:"max_bytes"`
+}
+
+// Options 解析选项。
+//
+// 内存占用上限约为：测试数 × Retention 允许的输出字节数 + 单行 MaxLineBytes，
+// 测试结束后只保留写入测试详情的输出，输出缓冲随即释放；
+// 超时的协程转储和包级输出按同样的 Retention 限制；测试详情、基准测试结果等结构化数据不受限制
+type Options struct {
+	// 单行保留的字节上限，超出部分丢弃并追加截断标记，0 表示不限制；
+	// JSON 日志中被截断的行只截断 Output 字段，事件本身仍会被解析
+	MaxLineBytes int
+	Retention    OutputRetention
+	// 流式事件处理函数，可以为 nil
+	Handler EventHandler
+}
+
+// DefaultOptions 返回 ParseTestLog/ParseTestTextLog 使用的默认选项
+func DefaultOptions() Options {
+	return Options{
+		MaxLineBytes: DefaultMaxLineBytes,
+		Retention:    OutputRetention{MaxBytes: DefaultMaxOutputBytes},
+	}
+}
+
+// truncationMarker 输出被截断时插入的标记
+func truncationMarker(lines, bytes int) string {
+	return fmt.Sprintf("... [%d lines, %d bytes of output truncated] ...", lines, bytes)
+}
+
+// truncatedLineMarker 超长行被截断时追加的标记
+func truncatedLineMarker(bytes int) string {
+	return fmt.Sprintf(" ... [%d bytes truncated]", bytes)
+}
+
+// outputBuffer 按 OutputRetention 保留输出的开头和末尾
+type outputBuffer struct {
+	retention OutputRetention
+	// 行之间的分隔符：文本日志为 "\n"，JSON 日志的 Output 自带换行为 ""
+	separator string
+
+	head      []string
+	headBytes int
+	tail      []string
+	tailBytes int
+	overflow  bool
+
+	droppedLines int
+	droppedBytes int
+}
+
+// newOutputBuffer 创建输出缓冲
+func newOutputBuffer(retention OutputRetention, separator string) *outputBuffer {
+	return &outputBuffer{retention: retention, separator: separator}
+}
+
+// lineWindow 判断是否设置了行数窗口
+func (b *outputBuffer) lineWindow() bool {
+	return b.retention.HeadLines > 0 || b.retention.TailLines > 0
+}
+
+// append 追加一行输出，超出上限时丢弃中间的行
+func (b *outputBuffer) append(lines ...string) {
+	for _, line := range lines {
+		b.appendLine(line)
+	}
+}
+
+// appendLine 追加一行输出
+func (b *outputBuffer) appendLine(line string) {
+	// 超过半个窗口的行无论放在开头还是末尾都放不下，截断到剩余空间而不是整行丢弃
+	if half := b.retention.MaxBytes / 2; b.retention.MaxBytes > 0 && len(line) > half {
+		room := b.retention.MaxBytes - half
+		if !b.overflow && b.fitsHead(0) && b.headBytes < half {
+			room = half - b.headBytes
+		}
+		line = b.truncateLine(line, room)
+	}
+	
+	if !b.overflow && b.fitsHead(len(line)) {
+		b.head = append(b.head, line)
+		b.headBytes += len(line)
+		return
+	}
+	b.overflow = true
+	
+	b.tail = append(b.tail, line)
+	b.tailBytes += len(line)
+	maxTailBytes := b.retention.MaxBytes - b.retention.MaxBytes/2
+	for len(b.tail) > 0 {
+		tooManyLines := b.lineWindow() && len(b.tail) > b.retention.TailLines
+		tooManyBytes := b.retention.MaxBytes > 0 && b.tailBytes > maxTailBytes
+		if !tooManyLines && !tooManyBytes {
+			break
+		}
+		b.droppedLines++
+		b.droppedBytes += len(b.tail[0])
+		b.tailBytes -= len(b.tail[0])
+		b.tail = b.tail[1:]
+	}
+}
+
+// truncateLine 将一行截断到 room 字节以内，保留开头并追加截断标记；行尾的换行总是保留
+func (b *outputBuffer) truncateLine(line string, room int) string {
+	newline := ""
+	if strings.HasSuffix(line, "\n") {
+		newline = "\n"
+		line = line[:len(line)-1]
+	}
+	keep := room - len(truncatedLineMarker(len(line))) - len(newline)
+	if keep < 0 {
+		keep = 0
+	}
+	if keep >= len(line) {
+		return line + newline
+	}
+	for keep > 0 && !utf8.RuneStart(line[keep]) {
+		keep--
+	}
+	return line[:keep] + truncatedLineMarker(len(line)-keep) + newline
+}
+
+// fitsHead 判断一行是否还能放入开头部分
+func (b *outputBuffer) fitsHead(size int) bool {
+	if b.lineWindow() && len(b.head) >= b.retention.HeadLines {
+		return false
+	}
+	return b.retention.MaxBytes == 0 || b.headBytes+size <= b.retention.MaxBytes/2
+}
+
+// lines 返回保留的输出行，中间被丢弃时插入截断标记
+func (b *outputBuffer) lines() []string {
+	if b.droppedLines == 0 {
+		return append(b.head[:len(b.head):len(b.head)], b.tail...)
+	}
+	marker := truncationMarker(b.droppedLines, b.droppedBytes)
+	if b.separator == "" {
+		marker += "\n"
+	}
+	lines := make([]string, 0, len(b.head)+len(b.tail)+1)
+	lines = append(lines, b.head...)
+	lines = append(lines, marker)
+	return append(lines, b.tail...)
+}
+
+// len 返回已追加的行数，包括被丢弃的行
+func (b *outputBuffer) len() int {
+	return len(b.head) + len(b.tail) + b.droppedLines
+}
+
+// String 返回保留的输出
+func (b *outputBuffer) String() string {
+	return strings.Join(b.lines(), b.separator)
+}
+
+// outputBuffers 按键管理输出缓冲
+type outputBuffers struct {
+	retention OutputRetention
+	separator string
+	buffers   map[string]*outputBuffer
+}
+
+// newOutputBuffers 创建输出缓冲集合
+func newOutputBuffers(retention OutputRetention, separator string) *outputBuffers {
+	return &outputBuffers{
+		retention: retention,
+		separator: separator,
+		buffers:   make(map[string]*outputBuffer),
+	}
+}
+
+// get 返回键对应的缓冲，不存在时创建
+func (o *outputBuffers) get(key string) *outputBuffer {
+	buffer, exists := o.buffers[key]
+	if !exists {
+		buffer = newOutputBuffer(o.retention, o.separator)
+		o.buffers[key] = buffer
+	}
+	return buffer
+}
+
+// append 向键对应的缓冲追加输出
+func (o *outputBuffers) append(key string, lines ...string) {
+	o.get(key).append(lines...)
+}
+
+// has 判断键对应的缓冲是否存在
+func (o *outputBuffers) has(key string) bool {
+	_, exists := o.buffers[key]
+	return exists
+}
+
+// release 释放键对应的缓冲
+func (o *outputBuffers) release(key string) {
+	delete(o.buffers, key)
+}
+
+// reset 清空键对应的缓冲
+func (o *outputBuffers) reset(key string) {
+	o.buffers[key] = newOutputBuffer(o.retention, o.separator)
+}
+
+// String 返回键对应的输出，不存在时返回空字符串
+func (o *outputBuffers) String(key string) string {
+	if buffer, exists := o.buffers[key]; exists {
+		return buffer.String()
+	}
+	return ""
+}
+
+// len 返回键对应的缓冲已追加的行数
+func (o *outputBuffers) len(key string) int {
+	if buffer, exists := o.buffers[key]; exists {
+		return buffer.len()
+	}
+	return 0
+}
+
+// lines 返回键对应的输出行
+func (o *outputBuffers) lines(key string) []string {
+	if buffer, exists := o.buffers[key]; exists {
+		return buffer.lines()
+	}
+	return nil
+}
+
+// lineReader 逐行读取任意长度的行，与 bufio.Scanner 不同，超长的行不会导致读取失败；
+// 超过 maxBytes 的部分直接丢弃，只记录丢弃的字节数
+type lineReader struct {
+	reader    *bufio.Reader
+	maxBytes  int
+	line      []byte
+	truncated int
+	err       error
+}
+
+// newLineReader 创建行读取器，maxBytes 为 0 表示不限制单行长度
+func newLineReader(reader io.Reader, maxBytes int) *lineReader {
+	return &lineReader{
+		reader:   bufio.NewReaderSize(reader, 64*1024),
+		maxBytes: maxBytes,
+	}
+}
+
+// Scan 读取下一行，没有更多行或出错时返回 false
+func (l *lineReader) Scan() bool {
+	if l.err != nil {
+		return false
+	}
+	l.line = l.line[:0]
+	l.truncated = 0
+	read := false
+	
+	for {
+		chunk, err := l.reader.ReadSlice('\n')
+		if len(chunk) > 0 {
+			read = true
+			l.keep(chunk)
+		}
+		if err == nil {
+			break
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		l.err = err
+		if !read {
+			return false
+		}
+		break
+	}
+	
+	// 与 bufio.ScanLines 一致，去掉行尾的 \n 和 \r
+	if n := len(l.line); n > 0 && l.line[n-1] == '\n' {
+		l.line = l.line[:n-1]
+	}
+	if n := len(l.line); n > 0 && l.line[n-1] == '\r' {
+		l.line = l.line[:n-1]
+	}
+	return true
+}
+
+// keep 保留一段内容，超过上限的部分计入截断字节数；行尾的换行总是保留
+func (l *lineReader) keep(chunk []byte) {
+	if l.maxBytes == 0 {
+		l.line = append(l.line, chunk...)
+		return
+	}
+	room := l.maxBytes - len(l.line)
+	if room < 0 {
+		room = 0
+	}
+	if len(chunk) <= room {
+		l.line = append(l.line, chunk...)
+		return
+	}
+	l.line = append(l.line, chunk[:room]...)
+	if chunk[len(chunk)-1] == '\n' {
+		l.truncated += len(chunk) - room - 1
+		l.line = append(l.line, '\n')
+	} else {
+		l.truncated += len(chunk) - room
+	}
+}
+
+// Text 返回当前行保留的内容
+func (l *lineReader) Text() string {
+	return string(l.line)
+}
+
+// Truncated 返回当前行被丢弃的字节数
+func (l *lineReader) Truncated() int {
+	return l.truncated
+}
+
+// Err 返回读取过程中遇到的非 EOF 错误
+func (l *lineReader) Err() error {
+	if l.err == io.EOF {
+		return nil
+	}
+	return l.err
+}
+
+// decodeTruncatedEvent 解析被截断的 test2json 事件行：test2json 的 Output 字段位于末尾，
+// 截断点通常落在 Output 中，补全字符串和对象后仍可得到事件，Output 末尾追加截断标记
+func decodeTruncatedEvent(line string, truncated int) (TestEvent, bool) {
+	var event TestEvent
+	idx := strings.Index(line, `"Output":"`)
+	if idx < 0 {
+		return event, false
+	}
+	
+	// 去掉截断点处不完整的转义序列
+	text := line
+	if i := strings.LastIndex(text, `\u`); i >= 0 && len(text)-i < 6 {
+		text = text[:i]
+	}
+	backslashes := 0
+	for i := len(text) - 1; i >= 0 && text[i] == '\\'; i-- {
+		backslashes++
+	}
+	if backslashes%2 == 1 {
+		text = text[:len(text)-1]
+	}
+	
+	if err := json.Unmarshal([]byte(text+`"}`), &event); err != nil {
+		return event, false
+	}
+	event.Output = strings.TrimRight(event.Output, "\n") + truncatedLineMarker(truncated) + "\n"
+	return event, true
+}
@@ -0,0 +1,208 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestOutputBuffer_HeadTailLines 测试按行数窗口保留开头和末尾并插入截断标记
+func TestOutputBuffer_HeadTailLines(t *testing.T) {
+	// Arrange
+	buffer := newOutputBuffer(OutputRetention{HeadLines: 2, TailLines: 2}, "\n")
+
+	// Act
+	for i := 1; i <= 10; i++ {
+		buffer.append(fmt.Sprintf("line %d", i))
+	}
+
+	// Assert
+	expected := "line 1\nline 2\n... [6 lines, 36 bytes of output truncated] ...\nline 9\nline 10"
+	if buffer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buffer.String())
+	}
+	if buffer.len() != 10 {
+		t.Errorf("Expected 10 appended lines, got %d", buffer.len())
+	}
+}
+
+// TestOutputBuffer_MaxBytes 测试按字节上限保留，开头和末尾各占一半
+func TestOutputBuffer_MaxBytes(t *testing.T) {
+	// Arrange
+	buffer := newOutputBuffer(OutputRetention{MaxBytes: 20}, "")
+
+	// Act
+	buffer.append("aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n")
+
+	// Assert
+	expected := "aaaa\nbbbb\n... [2 lines, 10 bytes of output truncated] ...\neeee\nffff\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buffer.String())
+	}
+}
+
+// TestOutputBuffer_Unlimited 测试零值不限制输出
+func TestOutputBuffer_Unlimited(t *testing.T) {
+	// Arrange
+	buffer := newOutputBuffer(OutputRetention{}, "\n")
+
+	// Act
+	for i := 0; i < 1000; i++ {
+		buffer.append("x")
+	}
+
+	// Assert
+	if len(buffer.lines()) != 1000 {
+		t.Errorf("Expected all 1000 lines to be kept, got %d", len(buffer.lines()))
+	}
+}
+
+// TestOutputBuffer_OversizedLine 测试超过半个窗口的行被截断到剩余空间而不是整行丢弃
+func TestOutputBuffer_OversizedLine(t *testing.T) {
+	// Arrange
+	buffer := newOutputBuffer(OutputRetention{MaxBytes: 200}, "\n")
+	blob := "    blob_test.go:9: " + strings.Repeat("x", 150)
+
+	// Act
+	buffer.append("=== RUN   TestBlob", blob, "--- FAIL: TestBlob (0.00s)")
+
+	// Assert
+	lines := buffer.lines()
+	if len(lines) != 3 {
+		t.Fatalf("Expected all 3 lines to be kept, got %q", lines)
+	}
+	if !strings.HasPrefix(lines[1], "    blob_test.go:9: xxx") || !strings.HasSuffix(lines[1], "bytes truncated]") {
+		t.Errorf("Expected the oversized line to keep its beginning with a marker, got %q", lines[1])
+	}
+	if len(lines[0])+len(lines[1]) > 100 {
+		t.Errorf("Expected the head to stay within half the window, got %d bytes", len(lines[0])+len(lines[1]))
+	}
+}
+
+// TestParseTestTextLog_OversizedLogLine 测试默认选项下大于半个窗口的 t.Log 行仍保留开头和日志条目
+func TestParseTestTextLog_OversizedLogLine(t *testing.T) {
+	// Arrange
+	blob := strings.Repeat("y", 600*1024)
+	log := "=== RUN   TestBlob\n    blob_test.go:9: " + blob + "\n--- FAIL: TestBlob (0.00s)\nFAIL\nFAIL\texample.com/blob\t0.010s\n"
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/blob.TestBlob"]
+	if detail == nil {
+		t.Fatal("Expected TestBlob detail")
+	}
+	if !strings.HasPrefix(detail.Output, "    blob_test.go:9: yyy") || !strings.Contains(detail.Output, "bytes truncated]") {
+		t.Errorf("Expected the oversized line to be truncated, not dropped, got %d bytes", len(detail.Output))
+	}
+	if len(detail.Logs) != 1 || detail.Logs[0].File != "blob_test.go" || detail.Logs[0].Line != 9 {
+		t.Errorf("Expected the t.Log entry to be kept, got %+v", detail.Logs)
+	}
+}
+
+// TestLineReader_LongLines 测试超过 bufio.Scanner 上限的长行可以读取，超过上限的部分被截断
+func TestLineReader_LongLines(t *testing.T) {
+	// Arrange
+	long := strings.Repeat("x", 200*1024)
+	input := "short\r\n" + long + "\nlast"
+
+	// Act
+	unlimited := newLineReader(strings.NewReader(input), 0)
+	var lines []string
+	for unlimited.Scan() {
+		lines = append(lines, unlimited.Text())
+	}
+	limited := newLineReader(strings.NewReader(input), 1024)
+	limited.Scan()
+	limited.Scan()
+
+	// Assert
+	if unlimited.Err() != nil || len(lines) != 3 || lines[0] != "short" || len(lines[1]) != len(long) || lines[2] != "last" {
+		t.Fatalf("Unexpected lines: %d lines, err %v", len(lines), unlimited.Err())
+	}
+	if len(limited.Text()) != 1024 || limited.Truncated() != len(long)-1024 {
+		t.Errorf("Expected 1024 kept bytes and %d truncated, got %d and %d", len(long)-1024, len(limited.Text()), limited.Truncated())
+	}
+}
+
+// TestParseTestLogWithOptions_TruncatedLine 测试 JSON 日志中超长输出行只截断 Output 字段
+func TestParseTestLogWithOptions_TruncatedLine(t *testing.T) {
+	// Arrange
+	blob := strings.Repeat(`{\"k\":\"v\"}`, 20000)
+	log := `{"Action":"run","Package":"example","Test":"TestBlob"}
+{"Action":"output","Package":"example","Test":"TestBlob","Output":"    blob_test.go:9: ` + blob + `\n"}
+{"Action":"fail","Package":"example","Test":"TestBlob","Elapsed":0}`
+	options := DefaultOptions()
+	options.MaxLineBytes = 4096
+
+	// Act
+	result, err := ParseTestLogWithOptions(strings.NewReader(log), options)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.TestBlob"]
+	if detail == nil || detail.Status != "fail" {
+		t.Fatalf("Expected failed TestBlob, got %+v", detail)
+	}
+	if len(detail.Output) > 4096 || !strings.Contains(detail.Output, "bytes truncated]") {
+		t.Errorf("Expected truncated output with marker, got %d bytes", len(detail.Output))
+	}
+	if !strings.HasPrefix(detail.Output, `    blob_test.go:9: {"k":"v"}`) {
+		t.Errorf("Expected output to keep its beginning, got %q", detail.Output[:40])
+	}
+}
+
+// TestParseTestTextLogWithOptions_Retention 测试文本日志按保留上限截断测试输出
+func TestParseTestTextLogWithOptions_Retention(t *testing.T) {
+	// Arrange
+	var log strings.Builder
+	log.WriteString("=== RUN   TestSoak\n")
+	for i := 1; i <= 10000; i++ {
+		fmt.Fprintf(&log, "    soak_test.go:20: iteration %d\n", i)
+	}
+	log.WriteString("    soak_test.go:30: leaked 3 goroutines\n--- FAIL: TestSoak (60.00s)\nFAIL\nFAIL\texample.com/soak\t60.010s\n")
+	options := Options{Retention: OutputRetention{HeadLines: 5, TailLines: 5}}
+
+	// Act
+	result, err := ParseTestTextLogWithOptions(strings.NewReader(log.String()), options)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/soak.TestSoak"]
+	lines := strings.Split(detail.Output, "\n")
+	if len(lines) != 11 || !strings.Contains(lines[5], "9991 lines") {
+		t.Fatalf("Expected 5 head lines, marker and 5 tail lines, got %d lines: %q", len(lines), lines[5])
+	}
+	if !strings.Contains(lines[10], "leaked 3 goroutines") {
+		t.Errorf("Expected the final line to be kept, got %q", lines[10])
+	}
+}
+
+// TestParseTestLog_OutputAfterFinish 测试结束后释放的输出缓冲在又有输出时恢复，结束前后的输出都保留
+func TestParseTestLog_OutputAfterFinish(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"output","Package":"example","Test":"TestA","Output":"    a_test.go:5: before\n"}
+{"Action":"fail","Package":"example","Test":"TestA","Elapsed":0}
+{"Action":"output","Package":"example","Test":"TestA","Output":"    a_test.go:9: after\n"}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.TestA"]
+	if detail.Output != "    a_test.go:5: before\n    a_test.go:9: after\n" {
+		t.Errorf("Expected output from before and after the finish event, got %q", detail.Output)
+	}
+}
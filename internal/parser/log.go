@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LogEntry t.Log/t.Error 等输出的一条日志
+type LogEntry struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`  // 多行消息的续行已去掉缩进
+	IsError bool   `json:"is_error"` // 是否为导致失败的日志：JSON 日志按 OutputType 判断，否则只认 testify 断言块
+}
+
+var (
+	//     foo_test.go:12: message，testify 的断言块在冒号后直接换行
+	logLinePattern = regexp.MustCompile(`^(\S+\.go):(\d+):(?: (.*))?$`)
+)
+
+// parseLogEntries 从测试输出中解析带源码位置的日志及其缩进续行，没有时返回 nil；
+// go test 输出不区分 t.Log 与 t.Error，IsError 只能在失败的测试中按 testify 断言块推断
+func parseLogEntries(output string, failed bool) []LogEntry {
+	var entries []LogEntry
+	current := -1
+	currentIndent := ""
+	
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		text := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(text)]
+		
+		if matches := logLinePattern.FindStringSubmatch(text); matches != nil {
+			lineNumber, _ := strconv.Atoi(matches[2])
+			entries = append(entries, LogEntry{
+				File:    matches[1],
+				Line:    lineNumber,
+				Message: matches[3],
+			})
+			current = len(entries) - 1
+			currentIndent = indent
+			continue
+		}
+		
+		// 续行比日志行多缩进一级（-v 模式为 4 个空格，旧版为一个制表符）
+		if current >= 0 && text != "" && len(indent) > len(currentIndent) && strings.HasPrefix(line, currentIndent) {
+			continuation := strings.TrimPrefix(line, currentIndent)
+			if strings.HasPrefix(continuation, "    ") {
+				continuation = continuation[4:]
+			} else {
+				continuation = strings.TrimPrefix(continuation, "\t")
+			}
+			entry := &entries[current]
+			if entry.Message == "" {
+				entry.Message = continuation
+			} else {
+				entry.Message += "\n" + continuation
+			}
+			continue
+		}
+		current = -1
+	}
+	
+	if failed {
+		markErrorEntries(entries)
+	}
+	return entries
+}
+
+// markErrorEntries 标记失败测试中的 testify 断言块；文本日志不区分 t.Log 与 t.Error，
+// 最后一条日志或 got/want 等措辞都可能只是普通输出，不作为判断依据
+func markErrorEntries(entries []LogEntry) {
+	for i := range entries {
+		if strings.Contains(entries[i].Message, "Error Trace:") {
+			entries[i].IsError = true
+		}
+	}
+}
+
+// markReportedErrors 按 test2json 的 OutputType 标记错误日志，reported 为标记为 error 的输出行（已去掉首尾空白），
+// 与日志的 file:line: 首行相同即为错误日志
+func markReportedErrors(entries []LogEntry, reported []string) {
+	lines := make(map[string]bool, len(reported))
+	for _, line := range reported {
+		lines[line] = true
+	}
+	for i := range entries {
+		first, _, _ := strings.Cut(entries[i].Message, "\n")
+		if lines[strings.TrimSpace(fmt.Sprintf("%s:%d: %s", entries[i].File, entries[i].Line, first))] {
+			entries[i].IsError = true
+		}
+	}
+}
+
+// formatLogEntries 将日志格式化为 file:line: message，多条日志之间空一行
+func formatLogEntries(entries []LogEntry) string {
+	parts := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		parts = append(parts, fmt.Sprintf("%s:%d: %s", entry.File, entry.Line, entry.Message))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ErrorLogs 返回推断为错误的日志
+func (d *TestDetail) ErrorLogs() []LogEntry {
+	errors := make([]LogEntry, 0)
+	for _, entry := range d.Logs {
+		if entry.IsError {
+			errors = append(errors, entry)
+		}
+	}
+	return errors
+}
@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseLogEntries 测试解析日志位置和多行续行，含 got/want 措辞的日志和最后一条日志都不被猜测为错误
+func TestParseLogEntries(t *testing.T) {
+	// Arrange
+	output := `    types_test.go:400: Nil callback functions handled correctly
+    types_test.go:405: retry: got 503, want 200 eventually
+    types_test.go:412: unexpected response:
+        status: 500
+        body: {"error":"boom"}`
+
+	// Act
+	entries := parseLogEntries(output, true)
+
+	// Assert
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %+v", entries)
+	}
+	if entries[0].File != "types_test.go" || entries[0].Line != 400 || entries[0].Message != "Nil callback functions handled correctly" {
+		t.Errorf("Unexpected first entry: %+v", entries[0])
+	}
+	for _, entry := range entries {
+		if entry.IsError {
+			t.Errorf("Expected no entry to be guessed as an error without OutputType, got %+v", entry)
+		}
+	}
+	if entries[2].Message != "unexpected response:\nstatus: 500\nbody: {\"error\":\"boom\"}" {
+		t.Errorf("Unexpected multi-line entry: %+v", entries[2])
+	}
+}
+
+// TestParseLogEntries_PassedTest 测试通过的测试不标记错误日志，旧版制表符缩进同样可解析
+func TestParseLogEntries_PassedTest(t *testing.T) {
+	// Arrange
+	output := "\tcache_test.go:9: expected miss on first lookup\n\t\tkey=a"
+
+	// Act
+	entries := parseLogEntries(output, false)
+
+	// Assert
+	if len(entries) != 1 || entries[0].Line != 9 || entries[0].Message != "expected miss on first lookup\nkey=a" {
+		t.Fatalf("Unexpected entries: %+v", entries)
+	}
+	if entries[0].IsError {
+		t.Errorf("Expected no error entries in a passed test")
+	}
+}
+
+// TestParseTestTextLog_LogEntries 测试文本日志中 testify 断言块作为一条错误日志，panic 栈帧不被当作日志
+func TestParseTestTextLog_LogEntries(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestUser
+    user_test.go:15: creating user
+    user_test.go:21: 
+        	Error Trace:	/src/user_test.go:21
+        	Error:      	Not equal: 
+        	            	expected: 1
+        	            	actual  : 2
+        	Test:       	TestUser
+--- FAIL: TestUser (0.00s)
+FAIL
+FAIL	example.com/user	0.010s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/user.TestUser"]
+	if len(detail.Logs) != 2 {
+		t.Fatalf("Expected 2 log entries, got %+v", detail.Logs)
+	}
+	errors := detail.ErrorLogs()
+	if len(errors) != 1 || errors[0].Line != 21 || !strings.Contains(errors[0].Message, "Error Trace:") {
+		t.Errorf("Expected testify block as the only error log, got %+v", errors)
+	}
+}
+
+// TestParseTestTextLog_ErrorFromAllLogs 测试文本日志无法区分错误日志时，失败信息列出全部日志而不是只取最后一条
+func TestParseTestTextLog_ErrorFromAllLogs(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestPar
+=== RUN   TestPar/y
+    a_test.go:17: bad y
+    a_test.go:19: done y
+--- FAIL: TestPar (0.00s)
+    --- FAIL: TestPar/y (0.00s)
+FAIL
+FAIL	example.com/par	0.010s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/par.TestPar/y"]
+	if detail == nil || len(detail.ErrorLogs()) != 0 {
+		t.Fatalf("Expected no guessed error logs, got %+v", detail)
+	}
+	if detail.Error != "a_test.go:17: bad y\n\na_test.go:19: done y" {
+		t.Errorf("Expected error listing all log entries, got %q", detail.Error)
+	}
+}
+
+// TestParseTestLog_OutputType 测试 go test -json 的 OutputType：只有 test2json 标记为 error 的日志算错误日志，
+// 之后的普通 t.Log 不算
+func TestParseTestLog_OutputType(t *testing.T) {
+	// Arrange
+	file, err := os.Open(filepath.Join("..", "..", "test_data", "fail_03.json"))
+	if err != nil {
+		t.Fatalf("Failed to open test data: %v", err)
+	}
+	defer file.Close()
+
+	// Act
+	result, err := ParseTestLog(file)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/ot.TestPar/y"]
+	if detail == nil || detail.Status != "fail" {
+		t.Fatalf("Expected failed TestPar/y, got %+v", detail)
+	}
+	if len(detail.Logs) != 3 {
+		t.Fatalf("Expected 3 log entries, got %+v", detail.Logs)
+	}
+	if detail.Logs[0].IsError || !detail.Logs[1].IsError || detail.Logs[2].IsError {
+		t.Errorf("Expected only the t.Errorf entry at line 8 to be an error, got %+v", detail.Logs)
+	}
+	if detail.Logs[1].Message != "bad y\nsecond line" {
+		t.Errorf("Expected error-continue line to belong to the error entry, got %q", detail.Logs[1].Message)
+	}
+	if detail.Error != "a_test.go:8: bad y\nsecond line" {
+		t.Errorf("Expected error from the reported entry, got %q", detail.Error)
+	}
+	if parent := result.TestDetails["example.com/ot.TestPar"]; parent.Error != "1 subtest(s) failed:\nTestPar/y: a_test.go:8: bad y" {
+		t.Errorf("Expected TestPar error to point at the failing subtest, got %q", parent.Error)
+	}
+	if ok := result.TestDetails["example.com/ot.TestOK"]; ok == nil || len(ok.ErrorLogs()) != 0 {
+		t.Errorf("Expected no error logs for TestOK, got %+v", ok)
+	}
+}
@@ -0,0 +1,228 @@
+package parser
+
+import "strings"
+
+// 包测试状态
+const (
+	PackagePass        = "pass"
+	PackageFail        = "fail"
+	PackageBuildFailed = "build_failed"
+	PackageSetupFailed = "setup_failed"
+	PackageNoTestFiles = "no_test_files"
+	PackageUnknown     = "unknown" // 没有看到包汇总行
+)
+
+// 没有失败测试的包失败原因
+const (
+	FailureCausePanic         = "panic"          // init()、TestMain 或测试外的 panic / fatal error
+	FailureCauseOSExit        = "os_exit"        // 测试过程中调用了 os.Exit
+	FailureCauseGoroutineLeak = "goroutine_leak" // goleak 等协程泄漏检查失败
+	FailureCauseExitStatus    = "exit_status"    // 测试二进制以非零状态退出，通常是 TestMain 返回非零
+	FailureCauseUnknown       = "unknown"
+)
+
+// PackageResult 包级测试结果
+type PackageResult struct {
+	Package    string           `json:"package"`
+	Status     string           `json:"status"`
+	Elapsed    float64          `json:"elapsed"`
+	Cached     bool             `json:"cached"`
+	Coverage   *PackageCoverage `json:"coverage,omitempty"`
+	Tests      int              `json:"tests"`
+	Passed     int              `json:"passed"`
+	Failed     int              `json:"failed"`
+	Skipped    int              `json:"skipped"`
+	TimedOut   int              `json:"timed_out"`
+	Incomplete int              `json:"incomplete"`
+	Output     string           `json:"output"` // 不属于任何测试的包级输出，如 TestMain 的打印、exit status
+
+	// 包失败但没有失败的测试时，记录失败原因；运行被中断的包只记录终止原因，FailureCause 为空
+	FailureCause  string     `json:"failure_cause,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	Panic         *PanicInfo `json:"panic,omitempty"`
+}
+
+// IsFailed 判断包是否失败（测试失败、编译失败或初始化失败）
+func (p PackageResult) IsFailed() bool {
+	return p.Status == PackageFail || p.Status == PackageBuildFailed || p.Status == PackageSetupFailed
+}
+
+// packageCollector 收集包状态和包级输出，测试计数和覆盖率在解析结束时统一汇总
+type packageCollector struct {
+	packages map[string]*PackageResult
+	outputs  *outputBuffers
+}
+
+// newPackageCollector 创建包结果收集器，包级输出按 retention 保留
+func newPackageCollector(retention OutputRetention) *packageCollector {
+	return &packageCollector{
+		packages: make(map[string]*PackageResult),
+		outputs:  newOutputBuffers(retention, "\n"),
+	}
+}
+
+// get 返回包结果，不存在时创建
+func (c *packageCollector) get(pkg string) *PackageResult {
+	result, exists := c.packages[pkg]
+	if !exists {
+		result = &PackageResult{Package: pkg, Status: PackageUnknown}
+		c.packages[pkg] = result
+	}
+	return result
+}
+
+// appendOutput 追加包级输出
+func (c *packageCollector) appendOutput(pkg string, lines ...string) {
+	c.outputs.append(pkg, lines...)
+}
+
+// apply 按包出现顺序写入结果，并汇总各包的测试计数和覆盖率
+func (c *packageCollector) apply(result *TestResult) {
+	for _, pkg := range result.Packages {
+		if pkg == "" {
+			continue
+		}
+		packageResult := c.get(pkg)
+		packageResult.Output = c.outputs.String(pkg)
+		if coverage, ok := result.CoverageFor(pkg); ok {
+			packageResult.Coverage = &coverage
+		}
+	}
+	
+	for _, detail := range result.TestDetails {
+		packageResult, exists := c.packages[detail.Package]
+		if !exists {
+			continue
+		}
+		switch detail.Status {
+		case "pass":
+			packageResult.Passed++
+		case "fail":
+			packageResult.Failed++
+		case "skip":
+			packageResult.Skipped++
+		case "timeout":
+			packageResult.TimedOut++
+		case "incomplete":
+			packageResult.Incomplete++
+		default:
+			continue
+		}
+		packageResult.Tests++
+	}
+	
+	for _, pkg := range result.Packages {
+		if packageResult, exists := c.packages[pkg]; exists && pkg != "" {
+			if packageResult.Status == PackageFail && packageResult.Failed == 0 && packageResult.TimedOut == 0 {
+				if packageResult.Incomplete > 0 {
+					// 运行被中断的包已有终止信息，不算作没有失败测试的包失败
+					packageResult.FailureReason = terminationReason(result, pkg)
+				} else {
+					explainPackageFailure(packageResult)
+					result.PackageFailures++
+				}
+			}
+			result.PackageResults = append(result.PackageResults, *packageResult)
+		}
+	}
+}
+
+// terminationReason 返回包中断时记录的终止原因
+func terminationReason(result *TestResult, pkg string) string {
+	for _, termination := range result.Terminations {
+		if termination.Package == pkg {
+			return termination.Description()
+		}
+	}
+	return ""
+}
+
+// explainPackageFailure 从包级输出推断没有失败测试的包为什么失败
+func explainPackageFailure(packageResult *PackageResult) {
+	output := packageResult.Output
+	packageResult.Panic = parsePanic(output)
+	switch {
+	case strings.Contains(output, "unexpected call to os.Exit"):
+		packageResult.FailureCause = FailureCauseOSExit
+	case strings.Contains(output, "found unexpected goroutines") || strings.Contains(output, "goroutine leak"):
+		packageResult.FailureCause = FailureCauseGoroutineLeak
+	case packageResult.Panic != nil:
+		packageResult.FailureCause = FailureCausePanic
+	case strings.Contains(output, "exit status "):
+		packageResult.FailureCause = FailureCauseExitStatus
+	default:
+		packageResult.FailureCause = FailureCauseUnknown
+	}
+	
+	switch {
+	case packageResult.Panic != nil:
+		packageResult.FailureReason = packageResult.Panic.Kind + ": " + packageResult.Panic.Value
+	case output != "":
+		packageResult.FailureReason = output
+	default:
+		packageResult.FailureReason = "package failed without any failing test or output"
+	}
+}
+
+// LookupPackage 返回指定包的结果
+func (r *TestResult) LookupPackage(pkg string) (*PackageResult, bool) {
+	for i := range r.PackageResults {
+		if r.PackageResults[i].Package == pkg {
+			return &r.PackageResults[i], true
+		}
+	}
+	return nil, false
+}
+
+// FailedPackages 返回失败的包
+func (r *TestResult) FailedPackages() []PackageResult {
+	failed := make([]PackageResult, 0)
+	for _, packageResult := range r.PackageResults {
+		if packageResult.IsFailed() {
+			failed = append(failed, packageResult)
+		}
+	}
+	return failed
+}
+
+// processPackageEvent 处理 test2json 中 Test 为空的包级事件
+func processPackageEvent(packageResult *PackageResult, packages *packageCollector, event TestEvent) {
+	switch event.Action {
+	case "output":
+		output := strings.TrimRight(event.Output, "\n")
+		trimmed := strings.TrimSpace(output)
+		switch {
+		case trimmed == "" || trimmed == "PASS" || trimmed == "FAIL" || strings.HasPrefix(trimmed, "coverage: "):
+		case strings.HasPrefix(trimmed, "ok  "):
+			packageResult.Cached = strings.Contains(trimmed, "(cached)")
+		case strings.HasPrefix(trimmed, "FAIL\t"):
+			if strings.Contains(trimmed, "[build failed]") {
+				packageResult.Status = PackageBuildFailed
+			} else if strings.Contains(trimmed, "[setup failed]") {
+				packageResult.Status = PackageSetupFailed
+			}
+		case strings.HasPrefix(trimmed, "?   "):
+		case parseNoTestFilesCoverage(trimmed) != nil:
+			// -cover 下没有测试文件的包只输出覆盖率行，随后是 pass 事件
+			packageResult.Status = PackageNoTestFiles
+		default:
+			packages.appendOutput(event.Package, output)
+		}
+	case "pass":
+		if packageResult.Status != PackageNoTestFiles {
+			packageResult.Status = PackagePass
+		}
+		packageResult.Elapsed = event.Elapsed
+	case "fail":
+		if event.FailedBuild != "" {
+			packageResult.Status = PackageBuildFailed
+		} else if packageResult.Status != PackageBuildFailed && packageResult.Status != PackageSetupFailed {
+			packageResult.Status = PackageFail
+		}
+		packageResult.Elapsed = event.Elapsed
+	case "skip":
+		// 只有没有测试文件的包会整体跳过
+		packageResult.Status = PackageNoTestFiles
+		packageResult.Elapsed = event.Elapsed
+	}
+}
@@ -0,0 +1,211 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_PackageResults 测试文本日志中各类包汇总行的状态、耗时、缓存和计数
+func TestParseTestTextLog_PackageResults(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestParse
+--- PASS: TestParse (0.00s)
+=== RUN   TestStore
+    store_test.go:12: boom
+--- FAIL: TestStore (0.01s)
+=== RUN   TestSkip
+--- SKIP: TestSkip (0.00s)
+FAIL
+exit status 1
+FAIL	example.com/app/store	0.210s
+ok  	example.com/app/parse	(cached)	coverage: 80.0% of statements
+?   	example.com/app/cmd	[no test files]
+ok  	example.com/app/empty	0.005s [no tests to run]
+FAIL	example.com/app/broken [build failed]
+FAIL	example.com/app/setup [setup failed]`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.PackageResults) != 6 {
+		t.Fatalf("Expected 6 package results, got %+v", result.PackageResults)
+	}
+	store, ok := result.LookupPackage("example.com/app/store")
+	if !ok || store.Status != PackageFail || store.Elapsed != 0.21 {
+		t.Fatalf("Unexpected store package: %+v", store)
+	}
+	if store.Tests != 3 || store.Passed != 1 || store.Failed != 1 || store.Skipped != 1 {
+		t.Errorf("Unexpected store counts: %+v", store)
+	}
+	if store.Output != "exit status 1" {
+		t.Errorf("Expected package output 'exit status 1', got %q", store.Output)
+	}
+	parse, _ := result.LookupPackage("example.com/app/parse")
+	if parse.Status != PackagePass || !parse.Cached || parse.Coverage == nil || parse.Coverage.Percent != 80 {
+		t.Errorf("Unexpected parse package: %+v", parse)
+	}
+	if cmd, _ := result.LookupPackage("example.com/app/cmd"); cmd.Status != PackageNoTestFiles {
+		t.Errorf("Expected no_test_files for cmd, got %+v", cmd)
+	}
+	if empty, _ := result.LookupPackage("example.com/app/empty"); empty.Status != PackagePass || empty.Elapsed != 0.005 {
+		t.Errorf("Unexpected empty package: %+v", empty)
+	}
+	if broken, _ := result.LookupPackage("example.com/app/broken"); broken.Status != PackageBuildFailed {
+		t.Errorf("Expected build_failed for broken, got %+v", broken)
+	}
+	if setup, _ := result.LookupPackage("example.com/app/setup"); setup.Status != PackageSetupFailed {
+		t.Errorf("Expected setup_failed for setup, got %+v", setup)
+	}
+	if failed := result.FailedPackages(); len(failed) != 3 {
+		t.Errorf("Expected 3 failed packages, got %+v", failed)
+	}
+}
+
+// TestParseTestLog_PackageResults 测试 JSON 日志中的包级事件
+func TestParseTestLog_PackageResults(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example/a","Test":"TestA"}
+{"Action":"fail","Package":"example/a","Test":"TestA","Elapsed":0}
+{"Action":"output","Package":"example/a","Output":"FAIL\n"}
+{"Action":"output","Package":"example/a","Output":"exit status 1\n"}
+{"Action":"output","Package":"example/a","Output":"FAIL\texample/a\t0.020s\n"}
+{"Action":"fail","Package":"example/a","Elapsed":0.02}
+{"Action":"run","Package":"example/b","Test":"TestB"}
+{"Action":"pass","Package":"example/b","Test":"TestB","Elapsed":0}
+{"Action":"output","Package":"example/b","Output":"ok  \texample/b\t(cached)\n"}
+{"Action":"pass","Package":"example/b","Elapsed":0}
+{"Action":"output","Package":"example/c","Output":"?   \texample/c\t[no test files]\n"}
+{"Action":"skip","Package":"example/c","Elapsed":0}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.PackageResults) != 3 {
+		t.Fatalf("Expected 3 package results, got %+v", result.PackageResults)
+	}
+	a := result.PackageResults[0]
+	if a.Package != "example/a" || a.Status != PackageFail || a.Elapsed != 0.02 || a.Failed != 1 || a.Output != "exit status 1" {
+		t.Errorf("Unexpected package a: %+v", a)
+	}
+	b := result.PackageResults[1]
+	if b.Status != PackagePass || !b.Cached || b.Passed != 1 {
+		t.Errorf("Unexpected package b: %+v", b)
+	}
+	if c := result.PackageResults[2]; c.Status != PackageNoTestFiles || c.Tests != 0 {
+		t.Errorf("Unexpected package c: %+v", c)
+	}
+}
+
+// TestParseTestLog_NoTestFilesCoverage 测试 -cover 下没有测试文件的包在两种日志中都是 no_test_files
+func TestParseTestLog_NoTestFilesCoverage(t *testing.T) {
+	// Arrange
+	jsonLog := `{"Action":"start","Package":"example.com/app/cmd"}
+{"Action":"output","Package":"example.com/app/cmd","Output":"\texample.com/app/cmd\t\tcoverage: 0.0% of statements\n"}
+{"Action":"pass","Package":"example.com/app/cmd","Elapsed":0.01}`
+	textLog := "\texample.com/app/cmd\t\tcoverage: 0.0% of statements\n"
+
+	// Act
+	jsonResult, jsonErr := ParseTestLog(strings.NewReader(jsonLog))
+	textResult, textErr := ParseTestTextLog(strings.NewReader(textLog))
+
+	// Assert
+	if jsonErr != nil || textErr != nil {
+		t.Fatalf("Expected no errors, got %v / %v", jsonErr, textErr)
+	}
+	jsonPackage, jsonFound := jsonResult.LookupPackage("example.com/app/cmd")
+	textPackage, textFound := textResult.LookupPackage("example.com/app/cmd")
+	if !jsonFound || !textFound {
+		t.Fatalf("Expected cmd in both results, got %+v / %+v", jsonResult.PackageResults, textResult.PackageResults)
+	}
+	if jsonPackage.Status != PackageNoTestFiles || textPackage.Status != PackageNoTestFiles {
+		t.Errorf("Expected no_test_files in both parsers, got %q / %q", jsonPackage.Status, textPackage.Status)
+	}
+	if jsonPackage.Output != "" {
+		t.Errorf("Expected the coverage line not to be kept as package output, got %q", jsonPackage.Output)
+	}
+	if coverage, ok := jsonResult.CoverageFor("example.com/app/cmd"); !ok || coverage.Percent != 0 {
+		t.Errorf("Expected 0%% coverage for cmd, got %+v", jsonResult.Coverage)
+	}
+}
+
+// TestParseTestTextLog_PackageFailureWithoutFailingTest 测试 init() panic、TestMain 非零退出和协程泄漏导致的包失败
+func TestParseTestTextLog_PackageFailureWithoutFailingTest(t *testing.T) {
+	// Arrange
+	log := `panic: boom
+
+goroutine 1 [running]:
+example.com/app/store.init.0()
+	/src/store/store.go:10 +0x25
+FAIL	example.com/app/store	0.010s
+=== RUN   TestMainOnly
+--- PASS: TestMainOnly (0.00s)
+PASS
+exit status 3
+FAIL	example.com/app/main	0.020s
+=== RUN   TestLeak
+--- PASS: TestLeak (0.00s)
+PASS
+goleak: Errors on successful test run: found unexpected goroutines:
+[Goroutine 7 in state chan receive, with example.com/app/leak.worker on top of the stack:]
+FAIL	example.com/app/leak	0.030s
+ok  	example.com/app/fine	0.040s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.FailedTests != 0 {
+		t.Errorf("Expected no failing tests, got %d", result.FailedTests)
+	}
+	if result.PackageFailures != 3 {
+		t.Errorf("Expected 3 package failures, got %d", result.PackageFailures)
+	}
+	store, _ := result.LookupPackage("example.com/app/store")
+	if store.FailureCause != FailureCausePanic || store.FailureReason != "panic: boom" {
+		t.Errorf("Unexpected store failure: %q %q", store.FailureCause, store.FailureReason)
+	}
+	if store.Panic == nil || store.Panic.FirstUserFrame == nil || store.Panic.FirstUserFrame.Line != 10 {
+		t.Errorf("Expected init panic location, got %+v", store.Panic)
+	}
+	if main, _ := result.LookupPackage("example.com/app/main"); main.FailureCause != FailureCauseExitStatus || main.FailureReason != "exit status 3" {
+		t.Errorf("Unexpected main failure: %+v", main)
+	}
+	if leak, _ := result.LookupPackage("example.com/app/leak"); leak.FailureCause != FailureCauseGoroutineLeak {
+		t.Errorf("Unexpected leak failure: %+v", leak)
+	}
+	if fine, _ := result.LookupPackage("example.com/app/fine"); fine.FailureCause != "" {
+		t.Errorf("Expected no failure cause for passing package, got %+v", fine)
+	}
+}
+
+// TestParseTestLog_PackageFailureOSExit 测试 JSON 日志中测试调用 os.Exit(0) 导致的包失败
+func TestParseTestLog_PackageFailureOSExit(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"pass","Package":"example","Test":"TestA","Elapsed":0}
+{"Action":"output","Package":"example","Output":"panic: unexpected call to os.Exit(0) during test\n"}
+{"Action":"output","Package":"example","Output":"FAIL\texample\t0.010s\n"}
+{"Action":"fail","Package":"example","Elapsed":0.01}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.PackageFailures != 1 || result.PackageResults[0].FailureCause != FailureCauseOSExit {
+		t.Errorf("Expected os.Exit package failure, got %+v", result.PackageResults)
+	}
+}
@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StackFrame 协程栈中的一帧
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	IsUser   bool   `json:"is_user"` // 是否属于被测代码，false 表示 runtime/标准库/testing
+}
+
+// Goroutine 协程栈
+type Goroutine struct {
+	ID          int          `json:"id"`
+	State       string       `json:"state"`       // 方括号中的完整状态，如 "chan receive, 5 minutes"
+	WaitReason  string       `json:"wait_reason"` // 状态中的等待原因，如 "chan receive"
+	WaitMinutes int          `json:"wait_minutes,omitempty"`
+	Frames      []StackFrame `json:"frames"`
+	CreatedBy   *StackFrame  `json:"created_by,omitempty"`
+}
+
+// PanicInfo panic 或 fatal error 的结构化信息
+type PanicInfo struct {
+	Kind           string      `json:"kind"` // "panic" 或 "fatal error"
+	Value          string      `json:"value"`
+	Recovered      bool        `json:"recovered"`
+	Goroutines     []Goroutine `json:"goroutines"`
+	FirstUserFrame *StackFrame `json:"first_user_frame,omitempty"`
+}
+
+var (
+	// goroutine 7 [running]: 或 goroutine 7 gp=0x... m=0 mp=0x... [running]:
+	goroutineHeaderPattern = regexp.MustCompile(`^goroutine (\d+)(?: [^\[]*)? \[([^\]]+)\]:$`)
+	// 	/path/to/file.go:12 +0x1d
+	frameFilePattern = regexp.MustCompile(`^(.+):(\d+)(?: \+0x[0-9a-f]+)?$`)
+	// created by testing.(*T).Run in goroutine 1
+	createdByPattern = regexp.MustCompile(`^created by (\S+)(?: in goroutine \d+)?$`)
+	// testing 恢复后重新抛出的 panic 后缀，Go 1.25 起为 [recovered, repanicked]
+	recoveredSuffixPattern = regexp.MustCompile(`\s\[recovered(?:, repanicked)?\]$`)
+)
+
+// stdlibRoots 标准库的顶层路径，用于区分被测代码与 runtime/标准库
+var stdlibRoots = map[string]bool{
+	"archive": true, "bufio": true, "builtin": true, "bytes": true, "cmp": true, "compress": true,
+	"container": true, "context": true, "crypto": true, "database": true, "debug": true, "embed": true,
+	"encoding": true, "errors": true, "expvar": true, "flag": true, "fmt": true, "go": true, "hash": true,
+	"html": true, "image": true, "index": true, "internal": true, "io": true, "iter": true, "log": true,
+	"maps": true, "math": true, "mime": true, "net": true, "os": true, "panic": true, "path": true,
+	"plugin": true, "reflect": true, "regexp": true, "runtime": true, "slices": true, "sort": true,
+	"strconv": true, "strings": true, "sync": true, "syscall": true, "testing": true, "text": true,
+	"time": true, "unicode": true, "unique": true, "unsafe": true, "vendor": true, "weak": true,
+}
+
+// isPanicStart 判断一行是否为 panic 或 fatal error 的开始
+func isPanicStart(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "panic: ") || strings.HasPrefix(trimmed, "fatal error: ")
+}
+
+// isUserFunction 判断函数是否属于被测代码，go test 生成的 _testmain.go 由调用方另行排除
+func isUserFunction(function string) bool {
+	root := function
+	if idx := strings.Index(root, "/"); idx >= 0 {
+		root = root[:idx]
+	} else if idx := strings.Index(root, "."); idx >= 0 {
+		root = root[:idx]
+	}
+	return !stdlibRoots[root]
+}
+
+// functionName 去掉函数调用行末尾的参数列表
+func functionName(line string) string {
+	if strings.HasSuffix(line, ")") {
+		if idx := strings.LastIndex(line, "("); idx > 0 {
+			return line[:idx]
+		}
+	}
+	return line
+}
+
+// parsePanic 从测试输出中解析第一个 panic 或 fatal error 及其后的协程栈，没有时返回 nil
+func parsePanic(output string) *PanicInfo {
+	lines := strings.Split(output, "\n")
+	start := -1
+	for i, line := range lines {
+		if isPanicStart(strings.TrimSpace(line)) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+	
+	info := &PanicInfo{
+		Goroutines: make([]Goroutine, 0),
+	}
+	first := strings.TrimSpace(lines[start])
+	if strings.HasPrefix(first, "panic: ") {
+		info.Kind = "panic"
+		info.Value = strings.TrimPrefix(first, "panic: ")
+	} else {
+		info.Kind = "fatal error"
+		info.Value = strings.TrimPrefix(first, "fatal error: ")
+	}
+	if loc := recoveredSuffixPattern.FindStringIndex(info.Value); loc != nil {
+		info.Recovered = true
+		info.Value = info.Value[:loc[0]]
+	}
+	
+	info.Goroutines = parseGoroutines(lines[start+1:])
+	info.FirstUserFrame = firstUserFrame(info.Goroutines)
+	return info
+}
+
+// parseGoroutines 解析协程栈转储
+func parseGoroutines(lines []string) []Goroutine {
+	goroutines := make([]Goroutine, 0)
+	var current *Goroutine
+	pendingFunction := ""
+	pendingCreatedBy := false
+	
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(strings.TrimRight(line, "\r"))
+		if trimmed == "" {
+			continue
+		}
+		
+		if matches := goroutineHeaderPattern.FindStringSubmatch(trimmed); matches != nil {
+			id, _ := strconv.Atoi(matches[1])
+			reason, minutes := parseGoroutineState(matches[2])
+			goroutines = append(goroutines, Goroutine{
+				ID:          id,
+				State:       matches[2],
+				WaitReason:  reason,
+				WaitMinutes: minutes,
+				Frames:      make([]StackFrame, 0),
+			})
+			current = &goroutines[len(goroutines)-1]
+			pendingFunction = ""
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		
+		// 文件行缩进，紧跟在函数行之后
+		isIndented := line[0] == ' ' || line[0] == '\t'
+		if isIndented && pendingFunction != "" {
+			if matches := frameFilePattern.FindStringSubmatch(trimmed); matches != nil {
+				lineNum, _ := strconv.Atoi(matches[2])
+				frame := StackFrame{
+					Function: pendingFunction,
+					File:     matches[1],
+					Line:     lineNum,
+					IsUser:   isUserFunction(pendingFunction) && !strings.HasSuffix(matches[1], "_testmain.go"),
+				}
+				if pendingCreatedBy {
+					current.CreatedBy = &frame
+				} else {
+					current.Frames = append(current.Frames, frame)
+				}
+			}
+			pendingFunction = ""
+			continue
+		}
+		
+		if isIndented {
+			continue
+		}
+		if matches := createdByPattern.FindStringSubmatch(trimmed); matches != nil {
+			pendingFunction = matches[1]
+			pendingCreatedBy = true
+			continue
+		}
+		if strings.HasPrefix(trimmed, "...") {
+			continue
+		}
+		pendingFunction = functionName(trimmed)
+		pendingCreatedBy = false
+	}
+	
+	return goroutines
+}
+
+// firstUserFrame 返回第一个协程中第一个属于被测代码的帧，即崩溃发生的位置
+func firstUserFrame(goroutines []Goroutine) *StackFrame {
+	for _, goroutine := range goroutines {
+		for i := range goroutine.Frames {
+			if goroutine.Frames[i].IsUser {
+				frame := goroutine.Frames[i]
+				return &frame
+			}
+		}
+	}
+	return nil
+}
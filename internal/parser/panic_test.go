@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const recoveredPanicOutput = `--- FAIL: TestIndex (0.00s)
+panic: runtime error: index out of range [5] with length 3 [recovered]
+	panic: runtime error: index out of range [5] with length 3
+
+goroutine 7 [running]:
+testing.tRunner.func1.2({0x5a1b20, 0xc000016180})
+	/usr/local/go/src/testing/testing.go:1545 +0x238
+testing.tRunner.func1()
+	/usr/local/go/src/testing/testing.go:1548 +0x397
+panic({0x5a1b20?, 0xc000016180?})
+	/usr/local/go/src/runtime/panic.go:914 +0x21f
+example.com/app/store.(*Store).Get(...)
+	/home/dev/app/store/store.go:42
+example.com/app/store.TestIndex(0xc000007860?)
+	/home/dev/app/store/store_test.go:12 +0x1d
+testing.tRunner(0xc0000076c0, 0x5ce1d8)
+	/usr/local/go/src/testing/testing.go:1595 +0xff
+created by testing.(*T).Run in goroutine 1
+	/usr/local/go/src/testing/testing.go:1648 +0x3ad
+exit status 2`
+
+// TestParsePanic_RecoveredPanic 测试解析被 testing 恢复后重新抛出的 panic
+func TestParsePanic_RecoveredPanic(t *testing.T) {
+	// Act
+	info := parsePanic(recoveredPanicOutput)
+	
+	// Assert
+	if info == nil {
+		t.Fatal("Expected panic info, got nil")
+	}
+	if info.Kind != "panic" || !info.Recovered {
+		t.Errorf("Expected recovered panic, got kind=%s recovered=%v", info.Kind, info.Recovered)
+	}
+	if info.Value != "runtime error: index out of range [5] with length 3" {
+		t.Errorf("Unexpected panic value: %q", info.Value)
+	}
+	if len(info.Goroutines) != 1 {
+		t.Fatalf("Expected 1 goroutine, got %d", len(info.Goroutines))
+	}
+	goroutine := info.Goroutines[0]
+	if goroutine.ID != 7 || goroutine.State != "running" {
+		t.Errorf("Expected goroutine 7 [running], got %d [%s]", goroutine.ID, goroutine.State)
+	}
+	if len(goroutine.Frames) != 6 {
+		t.Fatalf("Expected 6 frames, got %d", len(goroutine.Frames))
+	}
+	if goroutine.Frames[2].Function != "panic" || goroutine.Frames[2].IsUser {
+		t.Errorf("Expected runtime panic frame, got %+v", goroutine.Frames[2])
+	}
+	if goroutine.CreatedBy == nil || goroutine.CreatedBy.Function != "testing.(*T).Run" || goroutine.CreatedBy.Line != 1648 {
+		t.Errorf("Unexpected created by frame: %+v", goroutine.CreatedBy)
+	}
+	frame := info.FirstUserFrame
+	if frame == nil {
+		t.Fatal("Expected first user frame, got nil")
+	}
+	if frame.Function != "example.com/app/store.(*Store).Get" || frame.File != "/home/dev/app/store/store.go" || frame.Line != 42 {
+		t.Errorf("Unexpected first user frame: %+v", frame)
+	}
+}
+
+// TestParsePanic_RepanickedSuffix 测试 Go 1.25 起的 [recovered, repanicked] 后缀
+func TestParsePanic_RepanickedSuffix(t *testing.T) {
+	// Arrange
+	output := "panic: boom [recovered, repanicked]\n\ngoroutine 7 [running]:\nexample.com/app.TestBoom(0xc000007a00)\n\t/home/dev/app/boom_test.go:9 +0x25"
+	
+	// Act
+	info := parsePanic(output)
+	
+	// Assert
+	if info == nil {
+		t.Fatal("Expected panic info, got nil")
+	}
+	if !info.Recovered || info.Value != "boom" {
+		t.Errorf("Expected recovered panic with value boom, got recovered=%v value=%q", info.Recovered, info.Value)
+	}
+}
+
+// TestParsePanic_FatalError 测试解析 fatal error 及多个协程
+func TestParsePanic_FatalError(t *testing.T) {
+	// Arrange
+	output := `fatal error: all goroutines are asleep - deadlock!
+	
+goroutine 1 [chan receive]:
+testing.(*T).Run(0xc0000076c0, {0x5c1f2e, 0x8}, 0x5ce1d8)
+	C:/Go/src/testing/testing.go:1649 +0x3c8
+main.main()
+	_testmain.go:47 +0x195
+	
+goroutine 6 [chan send]:
+myapp/worker.TestDeadlock(0x0?)
+	C:/src/myapp/worker/worker_test.go:9 +0x2d
+created by testing.(*T).Run in goroutine 1
+	C:/Go/src/testing/testing.go:1648 +0x3ad`
+	
+	// Act
+	info := parsePanic(output)
+	
+	// Assert
+	if info == nil || info.Kind != "fatal error" || info.Value != "all goroutines are asleep - deadlock!" {
+		t.Fatalf("Unexpected fatal error info: %+v", info)
+	}
+	if len(info.Goroutines) != 2 {
+		t.Fatalf("Expected 2 goroutines, got %d", len(info.Goroutines))
+	}
+	if info.Goroutines[1].State != "chan send" {
+		t.Errorf("Expected chan send state, got %s", info.Goroutines[1].State)
+	}
+	// _testmain.go 中的 main.main 是 go test 生成的代码，不算作被测代码
+	if info.FirstUserFrame == nil || info.FirstUserFrame.Function != "myapp/worker.TestDeadlock" {
+		t.Errorf("Expected myapp/worker.TestDeadlock as first user frame, got %+v", info.FirstUserFrame)
+	}
+	if frame := info.Goroutines[1].Frames[0]; !frame.IsUser || frame.File != "C:/src/myapp/worker/worker_test.go" || frame.Line != 9 {
+		t.Errorf("Unexpected test frame: %+v", frame)
+	}
+}
+
+// TestParsePanic_NoPanic 测试没有 panic 的输出
+func TestParsePanic_NoPanic(t *testing.T) {
+	if info := parsePanic("    store_test.go:10: expected 1, got 2"); info != nil {
+		t.Errorf("Expected nil, got %+v", info)
+	}
+}
+
+// TestParseTestTextLog_PanicAfterFailLine 测试文本格式中 --- FAIL 之后打印的 panic 栈归属
+func TestParseTestTextLog_PanicAfterFailLine(t *testing.T) {
+	// Arrange
+	testInput := "=== RUN   TestOK\n--- PASS: TestOK (0.00s)\n=== RUN   TestIndex\n" + recoveredPanicOutput + "\nFAIL\texample.com/app/store\t0.005s"
+	reader := strings.NewReader(testInput)
+	
+	// Act
+	result, err := ParseTestTextLog(reader)
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/app/store.TestIndex"]
+	if detail == nil || detail.Status != "fail" {
+		t.Fatalf("Expected failed TestIndex, got %+v", detail)
+	}
+	if detail.Panic == nil || detail.Panic.FirstUserFrame == nil || detail.Panic.FirstUserFrame.Line != 42 {
+		t.Errorf("Expected panic with first user frame at line 42, got %+v", detail.Panic)
+	}
+	if passed := result.TestDetails["example.com/app/store.TestOK"]; passed == nil || passed.Panic != nil {
+		t.Errorf("Expected TestOK without panic, got %+v", passed)
+	}
+}
+
+// TestParseTestLog_Panic 测试JSON格式中的 panic 解析
+func TestParseTestLog_Panic(t *testing.T) {
+	// Arrange
+	testInput := `{"Action":"run","Package":"example.com/app","Test":"TestNil"}
+{"Action":"output","Package":"example.com/app","Test":"TestNil","Output":"--- FAIL: TestNil (0.00s)\n"}
+{"Action":"output","Package":"example.com/app","Test":"TestNil","Output":"panic: runtime error: invalid memory address or nil pointer dereference [recovered]\n"}
+{"Action":"output","Package":"example.com/app","Test":"TestNil","Output":"goroutine 18 [running]:\n"}
+{"Action":"output","Package":"example.com/app","Test":"TestNil","Output":"example.com/app.TestNil(0xc000007860?)\n"}
+{"Action":"output","Package":"example.com/app","Test":"TestNil","Output":"\t/src/app/app_test.go:21 +0x1d\n"}
+{"Action":"fail","Package":"example.com/app","Test":"TestNil","Elapsed":0}`
+	reader := strings.NewReader(testInput)
+	
+	// Act
+	result, err := ParseTestLog(reader)
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/app.TestNil"]
+	if detail == nil || detail.Panic == nil {
+		t.Fatalf("Expected panic info, got %+v", detail)
+	}
+	if detail.Panic.Value != "runtime error: invalid memory address or nil pointer dereference" {
+		t.Errorf("Unexpected panic value: %q", detail.Panic.Value)
+	}
+	if frame := detail.Panic.FirstUserFrame; frame == nil || frame.File != "/src/app/app_test.go" || frame.Line != 21 {
+		t.Errorf("Unexpected first user frame: %+v", frame)
+	}
+}
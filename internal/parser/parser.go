@@ -1,32 +1,89 @@
 package parser
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// TestEvent go test -json 输出的事件结构
+// TestEvent go test -json 输出的事件结构，与 cmd/test2json 的文档一致。
+//
+// Action 取值：start、run、pause、cont、pass、bench、fail、output、skip，
+// Go 1.24 起编译阶段的 build-output、build-fail（此时 ImportPath 非空而 Package 为空），
+// 以及 Go 1.25 起 t.Attr 产生的 attr（Key/Value 为属性）。
+//
+// OutputType 标明 output 事件的来源：frame 为 === RUN、--- FAIL 等框架行，
+// error 为 t.Error/t.Fatal 输出的首行，error-continue 为其续行，普通输出为空
 type TestEvent struct {
-	Time    time.Time `json:"Time"`
-	Action  string    `json:"Action"`
-	Package string    `json:"Package"`
-	Test    string    `json:"Test"`
-	Output  string    `json:"Output"`
-	Elapsed float64   `json:"Elapsed"`
+	Time        time.Time `json:"Time"`
+	Action      string    `json:"Action"`
+	Package     string    `json:"Package"`
+	Test        string    `json:"Test"`
+	Output      string    `json:"Output"`
+	Elapsed     float64   `json:"Elapsed"`
+	ImportPath  string    `json:"ImportPath,omitempty"`  // 编译事件对应的包，如 "pkg [pkg.test]"
+	FailedBuild string    `json:"FailedBuild,omitempty"` // 包因编译失败而失败时为编译失败的 ImportPath
+	Key         string    `json:"Key,omitempty"`
+	Value       string    `json:"Value,omitempty"`
+	OutputType  string    `json:"OutputType,omitempty"`
 }
 
 // TestDetail 测试详细信息
 type TestDetail struct {
+	Package string  `json:"package"`
+	Name    string  `json:"name"`
+	Kind    string  `json:"kind"` // test、fuzz、benchmark 或 example
 	Status  string  `json:"status"`
 	Output  string  `json:"output"`
 	Error   string  `json:"error"`
 	Elapsed float64 `json:"elapsed"`
+
+	// t.Attr 设置的属性
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// 子测试层级：Parent/Children 为 TestDetails 中的键
+	Parent   string         `json:"parent,omitempty"`
+	Children []string       `json:"children,omitempty"`
+	Subtests *SubtestCounts `json:"subtests,omitempty"`
+
+	Panic      *PanicInfo  `json:"panic,omitempty"`
+	Assertions []Assertion `json:"assertions,omitempty"`
+	Diffs      []DiffBlock `json:"diffs,omitempty"`
+	Logs       []LogEntry  `json:"logs,omitempty"`
+	Skip       *SkipInfo   `json:"skip,omitempty"`
+
+	Timeout     string           `json:"timeout,omitempty"`     // 超时测试的 -timeout 设置值
+	Termination *TerminationInfo `json:"termination,omitempty"` // 未完成测试所在进程的终止信息
+	Races       []DataRace       `json:"races,omitempty"`
+	Fuzz        *FuzzInfo        `json:"fuzz,omitempty"`
+	Example     *ExampleOutput   `json:"example,omitempty"`
+}
+
+// 测试种类，由函数名前缀决定
+const (
+	KindTest      = "test"
+	KindFuzz      = "fuzz"
+	KindBenchmark = "benchmark"
+	KindExample   = "example"
+)
+
+// testKind 根据函数名前缀返回测试种类
+func testKind(name string) string {
+	switch {
+	case strings.HasPrefix(name, "Fuzz"):
+		return KindFuzz
+	case strings.HasPrefix(name, "Benchmark"):
+		return KindBenchmark
+	case strings.HasPrefix(name, "Example"):
+		return KindExample
+	default:
+		return KindTest
+	}
 }
 
 // TestResult 测试结果汇总
@@ -40,23 +97,186 @@ type TestResult struct {
 	SkippedTestNames []string               `json:"skipped_test_names"`
 	TestDetails      map[string]*TestDetail `json:"test_details"`
 	Packages         []string               `json:"packages"`
+	BuildDiagnostics []BuildDiagnostic      `json:"build_diagnostics"`
+
+	TimedOut          bool              `json:"timed_out"`
+	TimedOutTests     int               `json:"timed_out_tests"`
+	TimedOutTestNames []string          `json:"timed_out_test_names"`
+	Timeouts          []TimeoutInfo     `json:"timeouts,omitempty"`
+	Races             []DataRace        `json:"races"`
+	Benchmarks        []Benchmark       `json:"benchmarks"`
+	Coverage          []PackageCoverage `json:"coverage"`
+	PackageResults    []PackageResult   `json:"package_results"`
+	PackageFailures   int               `json:"package_failures"` // 没有失败测试但整体失败的包数
+
+	// 日志结束时仍在运行的测试（进程被杀死、OOM、日志截断）
+	Incomplete          bool              `json:"incomplete"`
+	IncompleteTests     int               `json:"incomplete_tests"`
+	IncompleteTestNames []string          `json:"incomplete_test_names"`
+	Terminations        []TerminationInfo `json:"terminations,omitempty"`
+}
+
+// TestKey 返回 (包名, 测试名) 组合后的唯一标识，TestDetails 以及各测试名列表均使用该标识
+// 包名未知时（例如文本日志中缺少 ok/FAIL 汇总行）直接返回测试名
+func TestKey(pkg, name string) string {
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
+// LookupTest 按包名和测试名查找测试详情
+// 未指定包名时，先按完整标识精确匹配，再按测试名在所有包中查找；
+// 若多个包中存在同名测试，返回歧义错误并列出这些包
+func (r *TestResult) LookupTest(pkg, name string) (*TestDetail, error) {
+	if pkg != "" {
+		if detail, exists := r.TestDetails[TestKey(pkg, name)]; exists {
+			return detail, nil
+		}
+		return nil, fmt.Errorf("test not found: %s in package %s", name, pkg)
+	}
+	
+	if detail, exists := r.TestDetails[name]; exists {
+		return detail, nil
+	}
+	
+	matches := make([]*TestDetail, 0)
+	for _, detail := range r.TestDetails {
+		if detail.Name == name {
+			matches = append(matches, detail)
+		}
+	}
+	
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("test not found: %s", name)
+	case 1:
+		return matches[0], nil
+	}
+	
+	packages := make([]string, 0, len(matches))
+	for _, detail := range matches {
+		packages = append(packages, detail.Package)
+	}
+	sort.Strings(packages)
+	return nil, fmt.Errorf("test name %s is ambiguous, found in packages: %s; specify the package", name, strings.Join(packages, ", "))
 }
 
-// ParseTestLog 解析 go test -json 输出
+// ParseTestLog 使用默认选项解析 go test -json 输出
 func ParseTestLog(reader io.Reader) (*TestResult, error) {
+	return ParseTestLogWithOptions(reader, DefaultOptions())
+}
+
+// StreamTestLog 边读取边解析 go test -json 输出，每个事件到达时调用 handler，读取结束后返回汇总结果；
+// handler 为 nil 时等同于 ParseTestLog
+func StreamTestLog(reader io.Reader, handler EventHandler) (*TestResult, error) {
+	options := DefaultOptions()
+	options.Handler = handler
+	return ParseTestLogWithOptions(reader, options)
+}
+
+// ParseTestLogWithOptions 按指定的行长度、输出保留上限和事件处理函数解析 go test -json 输出
+func ParseTestLogWithOptions(reader io.Reader, options Options) (*TestResult, error) {
 	result := &TestResult{
-		FailedTestNames:  make([]string, 0),
-		PassedTestNames:  make([]string, 0),
-		SkippedTestNames: make([]string, 0),
-		TestDetails:      make(map[string]*TestDetail),
-		Packages:         make([]string, 0),
+		FailedTestNames:     make([]string, 0),
+		PassedTestNames:     make([]string, 0),
+		SkippedTestNames:    make([]string, 0),
+		TimedOutTestNames:   make([]string, 0),
+		IncompleteTestNames: make([]string, 0),
+		TestDetails:         make(map[string]*TestDetail),
+		Packages:            make([]string, 0),
+		BuildDiagnostics:    make([]BuildDiagnostic, 0),
+		Races:               make([]DataRace, 0),
+		Benchmarks:          make([]Benchmark, 0),
+		Coverage:            make([]PackageCoverage, 0),
+		PackageResults:      make([]PackageResult, 0),
 	}
 	
 	packageSet := make(map[string]bool)
-	testOutputs := make(map[string][]string)
+	testOutputs := newOutputBuffers(options.Retention, "")
+	timeoutOutputs := newOutputBuffers(options.Retention, "\n")
+	terminations := make(map[string]*terminationState)
+	benchmarks := make(map[string]*benchmarkCollector)
+	// 测试结束后才到达的输出（如 Example 的 got:/want: 块）需要重新整理
+	lateOutputs := make(map[string]bool)
+	// 每个包中开始运行、可能还没有结束的基准测试
+	runningBenchmarks := make(map[string][]string)
+	lastExample := make(map[string]string)
+	// test2json 标记为 error 的输出行，日志带有 OutputType 时据此判断错误日志
+	hasOutputType := false
+	reportedErrors := make(map[string][]string)
+	coverage := newCoverageCollector()
+	packages := newPackageCollector(options.Retention)
+	diagnostics := newDiagnosticCollector()
+	emitter := &eventEmitter{handler: options.Handler}
 	
-	scanner := bufio.NewScanner(reader)
+	// reported 返回测试被标记为 error 的输出行，日志没有 OutputType（旧版 Go）时返回 nil
+	reported := func(key string) []string {
+		if !hasOutputType {
+			return nil
+		}
+		if lines := reportedErrors[key]; lines != nil {
+			return lines
+		}
+		return []string{}
+	}
+	
+	// appendTestOutput 追加测试输出；测试结束时输出缓冲已释放，结束后又有输出时先用整理好的输出恢复缓冲
+	appendTestOutput := func(key, output string) {
+		if detail, exists := result.TestDetails[key]; exists && detail.Status != "running" {
+			if !testOutputs.has(key) {
+				for _, previous := range strings.SplitAfter(detail.Output, "\n") {
+					if previous != "" {
+						testOutputs.append(key, previous)
+					}
+				}
+			}
+			lateOutputs[key] = true
+		}
+		testOutputs.append(key, output)
+	}
+	
+	// finishBenchmark 基准测试通过时没有 pass 事件，按 status 结束运行中的基准测试
+	finishBenchmark := func(key, status string) {
+		detail := result.TestDetails[key]
+		if status == "pass" {
+			result.PassedTests++
+			result.PassedTestNames = append(result.PassedTestNames, key)
+		} else {
+			result.FailedTests++
+			result.FailedTestNames = append(result.FailedTestNames, key)
+		}
+		finishEvent(result, TestEvent{Package: detail.Package, Test: detail.Name, Elapsed: detail.Elapsed}, key, status, testOutputs.String(key), reported(key))
+		testOutputs.release(key)
+		delete(lateOutputs, key)
+		if emitter.active() {
+			emitter.emit(Event{Type: finishEventType(status), Package: detail.Package, Test: detail.Name, Detail: detail})
+		}
+	}
+	
+	// closeBenchmarks 基准测试依次运行，没有结果行的基准测试（如只运行子基准测试的父基准测试）
+	// 在下一个测试开始或包结束时仍在运行即已结束；next 的父基准测试仍在运行，保持不变
+	closeBenchmarks := func(pkg, next, status string) {
+		remaining := runningBenchmarks[pkg][:0]
+		for _, key := range runningBenchmarks[pkg] {
+			detail := result.TestDetails[key]
+			if detail.Status != "running" {
+				continue
+			}
+			if strings.HasPrefix(next, detail.Name+"/") {
+				remaining = append(remaining, key)
+				continue
+			}
+			finishBenchmark(key, status)
+		}
+		runningBenchmarks[pkg] = remaining
+	}
+	
+	scanner := newLineReader(reader, options.MaxLineBytes)
 	for scanner.Scan() {
+		if emitter.err != nil {
+			return nil, emitter.err
+		}
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
 			continue
@@ -64,9 +284,32 @@ func ParseTestLog(reader io.Reader) (*TestResult, error) {
 		
 		var event TestEvent
 		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			// 跳过无法解析的行，可能是非JSON输出
+			// 只有被截断的行才尝试补全，其余非JSON输出可能是混入 stderr 的编译错误
+			ok := false
+			if truncated := scanner.Truncated(); truncated > 0 {
+				event, ok = decodeTruncatedEvent(line, truncated)
+			}
+			if !ok {
+				diagnostics.processLine(scanner.Text(), false)
+				continue
+			}
+		}
+		
+		if event.OutputType != "" {
+			hasOutputType = true
+		}
+		
+		// 编译阶段的事件不属于任何包的测试运行，编译输出按诊断解析
+		if event.Action == "build-output" || event.Action == "build-fail" {
+			diagnostics.setImportPath(event.ImportPath)
+			for _, output := range strings.Split(strings.TrimRight(event.Output, "\n"), "\n") {
+				diagnostics.processLine(output, false)
+			}
 			continue
 		}
+		if event.FailedBuild != "" {
+			diagnostics.assignPackage(event.Package)
+		}
 		
 		// 记录包信息
 		if event.Package != "" && !packageSet[event.Package] {
@@ -74,79 +317,169 @@ func ParseTestLog(reader io.Reader) (*TestResult, error) {
 			result.Packages = append(result.Packages, event.Package)
 		}
 		
+		// 超时 panic 之后的 running tests 列表和协程转储属于整个包，
+		// test2json 可能将其归到某个测试或包级输出，统一按包收集
+		if event.Action == "output" {
+			output := strings.TrimRight(event.Output, "\n")
+			if isTimeoutStart(strings.TrimSpace(output)) || timeoutOutputs.len(event.Package) > 0 {
+				timeoutOutputs.append(event.Package, output)
+			}
+			
+			// 进程终止信息（fatal error、signal: killed、exit status）可能出现在测试或包级输出中
+			state, exists := terminations[event.Package]
+			if !exists {
+				state = &terminationState{}
+				terminations[event.Package] = state
+			}
+			state.observe(strings.TrimSpace(output))
+		}
+		
+		// 基准测试结果可能出现在包级输出或基准测试自身的输出中
+		var benchmark *Benchmark
+		if event.Action == "output" || event.Action == "bench" {
+			collector, exists := benchmarks[event.Package]
+			if !exists {
+				collector = &benchmarkCollector{pkg: event.Package}
+				benchmarks[event.Package] = collector
+			}
+			if benchmark, _ = collector.processLine(event.Output); benchmark != nil {
+				result.Benchmarks = append(result.Benchmarks, *benchmark)
+			}
+		}
+		
+		// 包级输出中的覆盖率：单独的 coverage: 行或 ok 汇总行末尾
+		if event.Test == "" && event.Action == "output" {
+			output := strings.TrimSpace(event.Output)
+			if idx := strings.Index(output, "coverage: "); idx >= 0 {
+				coverage.record(parseCoverage(event.Package, output[idx:]))
+			}
+		}
+		
+		// 包级事件：汇总状态和不属于任何测试的输出
+		if event.Test == "" && event.Package != "" {
+			packageResult := packages.get(event.Package)
+			processPackageEvent(packageResult, packages, event)
+			switch event.Action {
+			case "output":
+				emitter.emit(Event{Type: EventOutput, Package: event.Package, Output: strings.TrimRight(event.Output, "\n")})
+			case "pass", "fail", "skip":
+				// 进程异常终止时仍在运行的基准测试就是崩溃的基准测试
+				status := "pass"
+				if terminations[event.Package].crashed() {
+					status = "fail"
+				}
+				closeBenchmarks(event.Package, "", status)
+				emitter.emit(Event{Type: EventPackageDone, Package: event.Package, Elapsed: event.Elapsed, Status: packageResult.Status})
+			}
+		}
+		
+		// 失败 Example 的 got:/want: 块可能被 test2json 归为包级输出，块之后的 FAIL 等包级输出不属于 Example
+		if event.Test == "" && lastExample[event.Package] != "" {
+			if event.Action != "output" || isExampleTerminator(strings.TrimSpace(event.Output)) {
+				delete(lastExample, event.Package)
+			} else {
+				appendTestOutput(lastExample[event.Package], event.Output)
+			}
+		}
+		
 		// 处理测试事件
 		if event.Test != "" {
+			key := TestKey(event.Package, event.Test)
+			
+			// 模糊测试失败时会再打印一行缩进的同名 --- FAIL，不重复计数
+			if detail, exists := result.TestDetails[key]; exists && detail.Status == event.Action && isFinishAction(event.Action) {
+				continue
+			}
+			
 			switch event.Action {
 			case "run":
-				// 测试开始运行
-				if _, exists := result.TestDetails[event.Test]; !exists {
-					result.TestDetails[event.Test] = &TestDetail{
-						Status: "running",
-						Output: "",
+				delete(lastExample, event.Package)
+				delete(reportedErrors, key)
+				closeBenchmarks(event.Package, event.Test, "pass")
+				if testKind(event.Test) == KindBenchmark {
+					runningBenchmarks[event.Package] = append(runningBenchmarks[event.Package], key)
+				}
+				
+				// 测试开始运行，同名测试重复运行（-count=N）时重新进入运行状态
+				if detail, exists := result.TestDetails[key]; exists {
+					detail.Status = "running"
+				} else {
+					result.TestDetails[key] = &TestDetail{
+						Package: event.Package,
+						Name:    event.Test,
+						Kind:    testKind(event.Test),
+						Status:  "running",
+						Output:  "",
 					}
 				}
+				emitter.emit(Event{Type: EventTestStarted, Package: event.Package, Test: event.Test})
 				
-			case "output":
-				// 收集测试输出
+			case "pause", "cont", "attr":
+				// 并行测试暂停/恢复，仍处于运行状态；日志从中途开始时补建详情
+				detail, exists := result.TestDetails[key]
+				if !exists {
+					detail = &TestDetail{
+						Package: event.Package,
+						Name:    event.Test,
+						Kind:    testKind(event.Test),
+						Status:  "running",
+					}
+					result.TestDetails[key] = detail
+				}
+				if event.Action == "attr" {
+					detail.setAttribute(event.Key, event.Value)
+				}
+				
+			case "output", "bench":
+				// 收集测试输出，bench 事件是基准测试的结果行
 				if event.Output != "" {
-					testOutputs[event.Test] = append(testOutputs[event.Test], event.Output)
+					appendTestOutput(key, event.Output)
+					if event.OutputType == "error" {
+						reportedErrors[key] = append(reportedErrors[key], strings.TrimSpace(event.Output))
+					}
+					emitter.emit(Event{Type: EventOutput, Package: event.Package, Test: event.Test, Output: strings.TrimRight(event.Output, "\n")})
+				}
+				
+				// 结果行表示运行中的基准测试已通过
+				if detail, exists := result.TestDetails[key]; exists && detail.Status == "running" && benchmark != nil && benchmark.Name == event.Test {
+					finishBenchmark(key, "pass")
 				}
 				
 			case "pass":
 				// 测试通过
 				result.PassedTests++
-				result.PassedTestNames = append(result.PassedTestNames, event.Test)
-				
-				if detail, exists := result.TestDetails[event.Test]; exists {
-					detail.Status = "pass"
-					detail.Elapsed = event.Elapsed
-					detail.Output = strings.Join(testOutputs[event.Test], "")
-				} else {
-					result.TestDetails[event.Test] = &TestDetail{
-						Status:  "pass",
-						Elapsed: event.Elapsed,
-						Output:  strings.Join(testOutputs[event.Test], ""),
-					}
-				}
+				result.PassedTestNames = append(result.PassedTestNames, key)
+				finishEvent(result, event, key, "pass", testOutputs.String(key), reported(key))
 				
 			case "fail":
 				// 测试失败
 				result.FailedTests++
-				result.FailedTestNames = append(result.FailedTestNames, event.Test)
-				
-				output := strings.Join(testOutputs[event.Test], "")
-				errorMsg := extractErrorFromOutput(output)
-				
-				if detail, exists := result.TestDetails[event.Test]; exists {
-					detail.Status = "fail"
-					detail.Elapsed = event.Elapsed
-					detail.Output = output
-					detail.Error = errorMsg
-				} else {
-					result.TestDetails[event.Test] = &TestDetail{
-						Status:  "fail",
-						Elapsed: event.Elapsed,
-						Output:  output,
-						Error:   errorMsg,
-					}
+				result.FailedTestNames = append(result.FailedTestNames, key)
+				finishEvent(result, event, key, "fail", testOutputs.String(key), reported(key))
+				if testKind(event.Test) == KindExample {
+					lastExample[event.Package] = key
 				}
 				
 			case "skip":
 				// 测试跳过
 				result.SkippedTests++
-				result.SkippedTestNames = append(result.SkippedTestNames, event.Test)
-				
-				if detail, exists := result.TestDetails[event.Test]; exists {
-					detail.Status = "skip"
-					detail.Elapsed = event.Elapsed
-					detail.Output = strings.Join(testOutputs[event.Test], "")
-				} else {
-					result.TestDetails[event.Test] = &TestDetail{
-						Status:  "skip",
-						Elapsed: event.Elapsed,
-						Output:  strings.Join(testOutputs[event.Test], ""),
-					}
-				}
+				result.SkippedTestNames = append(result.SkippedTestNames, key)
+				finishEvent(result, event, key, "skip", testOutputs.String(key), reported(key))
+			}
+			
+			// 输出已写入测试详情，释放缓冲，避免每个测试的输出在内存中保留两份
+			if isFinishAction(event.Action) {
+				testOutputs.release(key)
+				delete(lateOutputs, key)
+			}
+			if isFinishAction(event.Action) && emitter.active() {
+				emitter.emit(Event{
+					Type:    finishEventType(event.Action),
+					Package: event.Package,
+					Test:    event.Test,
+					Elapsed: event.Elapsed,
+					Detail:  result.TestDetails[key],
+				})
 			}
 		}
 	}
@@ -154,13 +487,109 @@ func ParseTestLog(reader io.Reader) (*TestResult, error) {
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading test log: %w", err)
 	}
+	if emitter.err != nil {
+		return nil, emitter.err
+	}
+	
+	// 仍在运行的测试（例如进程崩溃）保留已收集的输出，结束后又有输出的测试重新整理
+	for key, detail := range result.TestDetails {
+		if detail.Status == "running" || lateOutputs[key] {
+			finalizeDetail(detail, testOutputs.String(key), reported(key))
+		}
+	}
+	
+	for _, pkg := range result.Packages {
+		applyTimeout(result, pkg, timeoutOutputs.lines(pkg))
+	}
+	applyTimeout(result, "", timeoutOutputs.lines(""))
+	
+	// 超时之外仍在运行的测试说明运行被中断；包已通过时 running 状态只是日志不完整（如缺少结束事件），不视为中断
+	for _, pkg := range result.Packages {
+		if packages.get(pkg).Status != PackagePass {
+			applyIncomplete(result, pkg, terminations[pkg])
+		}
+	}
+	applyIncomplete(result, "", terminations[""])
+	
+	coverage.apply(result)
+	packages.apply(result)
+	diagnostics.apply(result)
+	collectRaces(result)
+	buildSubtestTree(result)
 	
 	// 计算总测试数
-	result.TotalTests = result.PassedTests + result.FailedTests + result.SkippedTests
+	result.TotalTests = result.PassedTests + result.FailedTests + result.SkippedTests + result.TimedOutTests + result.IncompleteTests
 	
 	return result, nil
 }
 
+// isFinishAction 判断是否为测试结束事件
+func isFinishAction(action string) bool {
+	return action == "pass" || action == "fail" || action == "skip"
+}
+
+// finishEvent 处理 pass/fail/skip 事件，更新或创建测试详情
+func finishEvent(result *TestResult, event TestEvent, key, status string, output string, reported []string) {
+	detail, exists := result.TestDetails[key]
+	if !exists {
+		detail = &TestDetail{
+			Package: event.Package,
+			Name:    event.Test,
+		}
+		result.TestDetails[key] = detail
+	}
+	detail.Status = status
+	detail.Elapsed = event.Elapsed
+	finalizeDetail(detail, output, reported)
+}
+
+// finalizeDetail 设置测试输出，并从中提取错误信息、panic 栈、testify 断言和差异；
+// reported 为 test2json 标记为 error 的输出行，为 nil 时（文本日志、旧版 Go）按内容推断错误日志
+func finalizeDetail(detail *TestDetail, output string, reported []string) {
+	detail.Output = output
+	detail.Panic = parsePanic(output)
+	detail.Assertions = parseAssertions(output)
+	detail.Diffs = parseDiffs(output, detail.Assertions)
+	detail.Races = parseRaces(output)
+	if reported != nil {
+		detail.Logs = parseLogEntries(output, false)
+		if detail.Status == "fail" {
+			markReportedErrors(detail.Logs, reported)
+		}
+	} else {
+		detail.Logs = parseLogEntries(output, detail.Status == "fail")
+	}
+	detail.Skip = nil
+	if detail.Status == "skip" {
+		detail.Skip = parseSkip(detail.Logs)
+	}
+	detail.Kind = testKind(detail.Name)
+	switch detail.Kind {
+	case KindFuzz:
+		detail.Fuzz = parseFuzz(output)
+	case KindExample:
+		detail.Example = parseExample(output)
+	}
+	
+	if detail.Status == "fail" {
+		// testify 断言块结构固定，优先使用结构化结果，避免关键字匹配截断或误取无关行
+		if len(detail.Assertions) > 0 {
+			detail.Error = formatAssertions(detail.Assertions)
+		} else if detail.Example != nil {
+			detail.Error = formatExample(detail.Example)
+		} else if len(detail.Races) > 0 {
+			detail.Error = formatRaces(detail.Races)
+		} else if errorLogs := detail.ErrorLogs(); len(errorLogs) > 0 {
+			detail.Error = formatLogEntries(errorLogs)
+		} else if reported == nil && len(detail.Logs) > 0 {
+			// 无法区分哪条日志导致失败时列出全部日志
+			detail.Error = formatLogEntries(detail.Logs)
+		} else {
+			detail.Error = extractErrorFromOutput(output)
+		}
+	}
+}
+
 // extractErrorFromOutput 从测试输出中提取错误信息
 func extractErrorFromOutput(output string) string {
 	lines := strings.Split(output, "\n")
@@ -168,6 +597,9 @@ func extractErrorFromOutput(output string) string {
 	
 	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
+		if isFrameLine(trimmed) {
+			continue
+		}
 		
 		// 查找常见的错误模式
 		if strings.Contains(trimmed, "FAIL:") ||
@@ -185,217 +617,586 @@ func extractErrorFromOutput(output string) string {
 		return strings.Join(errorLines, "\n")
 	}
 	
-	// 如果没有找到特定的错误模式，返回整个输出的前几行
-	if len(lines) > 0 {
+	// 如果没有找到特定的错误模式，返回整个输出（不含框架行）的前几行
+	outputLines := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if !isFrameLine(strings.TrimSpace(line)) {
+			outputLines = append(outputLines, line)
+		}
+	}
+	if len(outputLines) > 0 {
 		maxLines := 5
-		if len(lines) < maxLines {
-			maxLines = len(lines)
+		if len(outputLines) < maxLines {
+			maxLines = len(outputLines)
 		}
-		return strings.Join(lines[:maxLines], "\n")
+		return strings.Join(outputLines[:maxLines], "\n")
 	}
 	
 	return "No error details available"
 }
 
-// ParseTestTextLog 解析 go test 普通文本输出
+// isFrameLine 判断是否为 go test 的测试框架行（=== RUN、--- FAIL 等），JSON 日志中这些行会出现在测试输出里
+func isFrameLine(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "=== ") ||
+		strings.HasPrefix(trimmed, "--- PASS:") ||
+		strings.HasPrefix(trimmed, "--- FAIL:") ||
+		strings.HasPrefix(trimmed, "--- SKIP:") ||
+		strings.HasPrefix(trimmed, "--- BENCH:") ||
+		trimmed == "PASS" || trimmed == "FAIL"
+}
+
+// packageBlock 文本日志中包名要到 ok/FAIL 汇总行才出现，
+// 因此先缓存当前包块内的测试，待包名确定后再写入 TestResult
+type packageBlock struct {
+	details   map[string]*TestDetail
+	outputs   *outputBuffers
+	completed []*TestDetail
+	retention OutputRetention
+	
+	// 测试结束后打印的内容（旧版 go 的 t.Log 输出、panic 栈）归属到最近结束的测试
+	trailingTest string
+	inPanic      bool
+	inExample    bool // Example 函数的 got:/want: 块不缩进
+	
+	// 测试超时 panic 及其后的 running tests 列表、协程转储
+	timeoutLines *outputBuffer
+	
+	// 没有 pkg: 头部时基准测试的包名同样要等到汇总行
+	benchmarks []Benchmark
+	
+	// 尚未归属到模糊测试的进度输出
+	fuzzLines *outputBuffer
+	
+	// 开始运行、可能还没有结束的基准测试
+	runningBenchmarks []string
+	
+	// 不属于任何测试的包级输出
+	packageOutput *outputBuffer
+	
+	// 进程终止相关的输出，用于解释未完成的测试
+	termination *terminationState
+}
+
+// newPackageBlock 创建新的包块，各类输出按 retention 保留
+func newPackageBlock(retention OutputRetention) *packageBlock {
+	block := &packageBlock{retention: retention}
+	block.reset()
+	return block
+}
+
+// reset 清空包块内缓存的测试和输出
+func (b *packageBlock) reset() {
+	b.details = make(map[string]*TestDetail)
+	b.outputs = newOutputBuffers(b.retention, "\n")
+	b.completed = make([]*TestDetail, 0)
+	b.timeoutLines = newOutputBuffer(b.retention, "\n")
+	b.benchmarks = nil
+	b.fuzzLines = newOutputBuffer(b.retention, "\n")
+	b.runningBenchmarks = nil
+	b.packageOutput = newOutputBuffer(b.retention, "\n")
+	b.termination = &terminationState{}
+	b.endTrailing()
+}
+
+// flush 为包块内的测试设置包名并写入结果，status 为包状态，没有汇总行时为 PackageUnknown
+func (b *packageBlock) flush(result *TestResult, pkg, status string) {
+	for name, detail := range b.details {
+		finalizeDetail(detail, b.outputs.String(name), nil)
+		detail.Package = pkg
+		result.TestDetails[TestKey(pkg, detail.Name)] = detail
+	}
+	applyTimeout(result, pkg, b.timeoutLines.lines())
+	if status != PackagePass {
+		applyIncomplete(result, pkg, b.termination)
+	}
+	
+	for _, benchmark := range b.benchmarks {
+		if benchmark.Package == "" {
+			benchmark.Package = pkg
+		}
+		result.Benchmarks = append(result.Benchmarks, benchmark)
+	}
+	
+	for _, detail := range b.completed {
+		key := TestKey(pkg, detail.Name)
+		switch detail.Status {
+		case "pass":
+			result.PassedTestNames = append(result.PassedTestNames, key)
+		case "fail":
+			result.FailedTestNames = append(result.FailedTestNames, key)
+		case "skip":
+			result.SkippedTestNames = append(result.SkippedTestNames, key)
+		}
+	}
+	
+	b.reset()
+}
+
+// endTrailing 结束最近测试的尾随输出
+func (b *packageBlock) endTrailing() {
+	b.trailingTest = ""
+	b.inPanic = false
+	b.inExample = false
+}
+
+// appendTrailing 将测试结束后的缩进输出或 panic 栈归属到最近结束的测试，返回该行是否被归属
+func (b *packageBlock) appendTrailing(line, trimmed string) bool {
+	if b.trailingTest == "" {
+		return false
+	}
+	if isPanicStart(trimmed) {
+		b.inPanic = true
+	}
+	if trimmed == "got:" && testKind(b.trailingTest) == KindExample {
+		b.inExample = true
+	}
+	if !b.inPanic && !b.inExample && line[0] != ' ' && line[0] != '\t' {
+		return false
+	}
+	b.outputs.append(b.trailingTest, line)
+	return true
+}
+
+// ParseTestTextLog 使用默认选项解析 go test 普通文本输出
 func ParseTestTextLog(reader io.Reader) (*TestResult, error) {
+	return ParseTestTextLogWithOptions(reader, DefaultOptions())
+}
+
+// StreamTestTextLog 边读取边解析 go test 普通文本输出，每个事件到达时调用 handler，读取结束后返回汇总结果；
+// handler 为 nil 时等同于 ParseTestTextLog
+func StreamTestTextLog(reader io.Reader, handler EventHandler) (*TestResult, error) {
+	options := DefaultOptions()
+	options.Handler = handler
+	return ParseTestTextLogWithOptions(reader, options)
+}
+
+// ParseTestTextLogWithOptions 按指定的行长度、输出保留上限和事件处理函数解析 go test 普通文本输出
+func ParseTestTextLogWithOptions(reader io.Reader, options Options) (*TestResult, error) {
 	result := &TestResult{
-		FailedTestNames:  make([]string, 0),
-		PassedTestNames:  make([]string, 0),
-		SkippedTestNames: make([]string, 0),
-		TestDetails:      make(map[string]*TestDetail),
-		Packages:         make([]string, 0),
+		FailedTestNames:     make([]string, 0),
+		PassedTestNames:     make([]string, 0),
+		SkippedTestNames:    make([]string, 0),
+		TimedOutTestNames:   make([]string, 0),
+		IncompleteTestNames: make([]string, 0),
+		TestDetails:         make(map[string]*TestDetail),
+		Packages:            make([]string, 0),
+		BuildDiagnostics:    make([]BuildDiagnostic, 0),
+		Races:               make([]DataRace, 0),
+		Benchmarks:          make([]Benchmark, 0),
+		Coverage:            make([]PackageCoverage, 0),
+		PackageResults:      make([]PackageResult, 0),
 	}
 	
 	packageSet := make(map[string]bool)
+	block := newPackageBlock(options.Retention)
 	currentTest := ""
-	currentOutput := make([]string, 0)
-	buildErrors := make([]string, 0)
+	diagnostics := newDiagnosticCollector()
+	benchmarks := &benchmarkCollector{}
+	coverage := newCoverageCollector()
+	packages := newPackageCollector(options.Retention)
+	emitter := &eventEmitter{handler: options.Handler}
+	// -v 模式下 coverage: 行单独出现在包汇总行之前
+	var pendingCoverage *PackageCoverage
 	
 	// 正则表达式模式
 	runPattern := regexp.MustCompile(`^=== RUN\s+(.+)$`)
+	pausePattern := regexp.MustCompile(`^=== PAUSE\s+(.+)$`)
+	// === ATTR  TestName key value，键不含空白，值可以包含空格
+	attrPattern := regexp.MustCompile(`^=== ATTR\s+(\S+)\s+(\S+)\s?(.*)$`)
+	// 并行测试的输出切换到另一个测试前会打印 === CONT（Go 1.20 起为 === NAME），名字为空表示包级输出
+	contPattern := regexp.MustCompile(`^=== (?:CONT|NAME)(?:\s+(.*))?$`)
 	passPattern := regexp.MustCompile(`^--- PASS:\s+(.+?)\s+\(([0-9.]+)s\)$`)
-	failPattern := regexp.MustCompile(`^--- FAIL:\s+(.+?)\s+\(([0-9.]+)s\)$`)
-	skipPattern := regexp.MustCompile(`^--- SKIP:\s+(.+?)\s+\(([0-9.]+)s\)$`)
-	okPattern := regexp.MustCompile(`^(ok|PASS)\s+(.+?)(?:\s+\(cached\))?(?:\s+([0-9.]+)s)?$`)
-	failPackagePattern := regexp.MustCompile(`^FAIL\s+(.+?)(?:\s+\[build failed\])?(?:\s+([0-9.]+)s)?$`)
-	buildErrorPattern := regexp.MustCompile(`^(.+?):\d+:\d+:\s+(.+)$`)
+	// 基准测试的 --- FAIL / --- SKIP 行没有耗时
+	failPattern := regexp.MustCompile(`^--- FAIL:\s+(.+?)(?:\s+\(([0-9.]+)s\))?$`)
+	skipPattern := regexp.MustCompile(`^--- SKIP:\s+(.+?)(?:\s+\(([0-9.]+)s\))?$`)
+	// 基准测试通过后打印 b.Log 输出前的标题行，名称带 GOMAXPROCS 后缀
+	benchPattern := regexp.MustCompile(`^--- BENCH:\s+(.+?)(?:\s+\(([0-9.]+)s\))?$`)
+	okPattern := regexp.MustCompile(`^(ok|PASS)\s+(.+?)(\s+\(cached\))?(?:\s+([0-9.]+)s)?(?:\s+\[no tests to run\])?(?:\s+(coverage: .+))?$`)
+	failPackagePattern := regexp.MustCompile(`^FAIL\s+(.+?)(\s+\[(build|setup) failed\])?(?:\s+([0-9.]+)s)?$`)
+	noTestFilesPattern := regexp.MustCompile(`^\?\s+(\S+)\s+\[no test files\]$`)
+	
+	// finishTest 记录测试结束状态，输出按测试名归属，嵌套子测试的输出不会被父测试吞掉；
+	// 输出、错误信息和 panic 栈在包块结束时统一整理
+	finishTest := func(testName, status string, elapsed float64) {
+		detail, exists := block.details[testName]
+		if !exists {
+			detail = &TestDetail{Name: testName}
+			block.details[testName] = detail
+		}
+		detail.Status = status
+		detail.Elapsed = elapsed
+		if testKind(testName) == KindFuzz && block.fuzzLines.len() > 0 {
+			lines := append(block.fuzzLines.lines(), block.outputs.lines(testName)...)
+			block.outputs.reset(testName)
+			block.outputs.append(testName, lines...)
+			block.fuzzLines = newOutputBuffer(options.Retention, "\n")
+		}
+		block.completed = append(block.completed, detail)
+		block.trailingTest = testName
+		block.inPanic = false
+		block.inExample = false
+		
+		currentTest = ""
+		
+		// 尾随输出和包名要到后面才确定，这里先按已收到的输出整理，包块结束时再整理一次
+		if emitter.active() {
+			finalizeDetail(detail, block.outputs.String(testName), nil)
+			emitter.emit(Event{Type: finishEventType(status), Test: testName, Elapsed: elapsed, Detail: detail})
+		}
+	}
+	
+	// closeBenchmarks 基准测试依次运行，没有结果行的基准测试（如只运行子基准测试的父基准测试）
+	// 在下一个测试开始或包结束时仍在运行即已结束；next 的父基准测试仍在运行，保持不变
+	closeBenchmarks := func(next, status string) {
+		remaining := block.runningBenchmarks[:0]
+		for _, name := range block.runningBenchmarks {
+			detail := block.details[name]
+			if detail.Status != "running" {
+				continue
+			}
+			if strings.HasPrefix(next, name+"/") {
+				remaining = append(remaining, name)
+				continue
+			}
+			if status == "pass" {
+				result.PassedTests++
+			} else {
+				result.FailedTests++
+			}
+			finishTest(name, status, 0)
+		}
+		block.runningBenchmarks = remaining
+	}
 	
-	scanner := bufio.NewScanner(reader)
+	// startTest 开始运行测试，之后的输出归属到该测试
+	startTest := func(testName string) {
+		closeBenchmarks(testName, "pass")
+		if testKind(testName) == KindBenchmark {
+			block.runningBenchmarks = append(block.runningBenchmarks, testName)
+		}
+		currentTest = testName
+		block.endTrailing()
+		
+		// 同名测试重复运行（-count=N）时先整理上一次的结果
+		if previous, exists := block.details[testName]; exists {
+			finalizeDetail(previous, block.outputs.String(testName), nil)
+		}
+		block.outputs.reset(testName)
+		
+		// 创建测试详情
+		block.details[testName] = &TestDetail{
+			Name:   testName,
+			Status: "running",
+			Output: "",
+		}
+		emitter.emit(Event{Type: EventTestStarted, Test: testName})
+	}
+	
+	// benchmarkName 将 --- BENCH: BenchmarkX-8 等行中带 GOMAXPROCS 后缀的名称还原为已记录的基准测试名
+	benchmarkName := func(testName string) string {
+		if matches := benchmarkNamePattern.FindStringSubmatch(testName); matches != nil && matches[2] != "" {
+			if _, exists := block.details[matches[1]]; exists {
+				return matches[1]
+			}
+		}
+		return testName
+	}
+	
+	// finishBenchmark 基准测试没有 --- PASS 行，结果行或 --- BENCH 行表示运行中的基准测试通过；
+	// 已结束的基准测试（-count=N 的后续结果）不重复计数，只将之后的 b.Log 输出归属到它。
+	// 非 -v 模式没有名称行，首次出现的结果行即表示基准测试及其父基准测试通过，与 -v 和 JSON 日志计数一致
+	finishBenchmark := func(testName string, elapsed float64) {
+		detail, exists := block.details[testName]
+		if !exists {
+			parts := strings.Split(testName, "/")
+			for i := 1; i < len(parts); i++ {
+				parent := strings.Join(parts[:i], "/")
+				if _, exists := block.details[parent]; !exists {
+					result.PassedTests++
+					finishTest(parent, "pass", 0)
+				}
+			}
+		}
+		if !exists || detail.Status == "running" {
+			result.PassedTests++
+			finishTest(testName, "pass", elapsed)
+			return
+		}
+		currentTest = ""
+		block.endTrailing()
+		if exists {
+			block.trailingTest = testName
+		}
+	}
+	
+	// recordPackage 记录包名和包状态，并将缓存的测试和包级输出归属到该包
+	recordPackage := func(packageName, status string, elapsed float64) *PackageResult {
+		if !packageSet[packageName] {
+			packageSet[packageName] = true
+			result.Packages = append(result.Packages, packageName)
+		}
+		packageResult := packages.get(packageName)
+		packageResult.Status = status
+		packageResult.Elapsed = elapsed
+		packages.appendOutput(packageName, block.packageOutput.lines()...)
+		
+		// 进程异常终止时仍在运行的基准测试就是崩溃的基准测试
+		if block.termination.crashed() {
+			closeBenchmarks("", "fail")
+		} else {
+			closeBenchmarks("", "pass")
+		}
+		block.flush(result, packageName, status)
+		emitter.emit(Event{Type: EventPackageDone, Package: packageName, Elapsed: elapsed, Status: status})
+		
+		// 基准测试头部只对当前包有效
+		benchmarks = &benchmarkCollector{}
+		return packageResult
+	}
+	
+	scanner := newLineReader(reader, options.MaxLineBytes)
 	for scanner.Scan() {
+		if emitter.err != nil {
+			return nil, emitter.err
+		}
 		line := scanner.Text()
+		if truncated := scanner.Truncated(); truncated > 0 {
+			line += truncatedLineMarker(truncated)
+		}
 		trimmed := strings.TrimSpace(line)
 		
 		if trimmed == "" {
 			continue
 		}
+		block.termination.observe(trimmed)
+		
+		// 检查编译错误（# pkg 头部、file:line:col: msg 及其续行），测试运行期间的输出不是编译错误
+		if diagnostics.processLine(line, currentTest != "") {
+			continue
+		}
 		
 		// 检查是否是测试运行开始
 		if matches := runPattern.FindStringSubmatch(trimmed); matches != nil {
-			// 保存之前测试的输出
-			if currentTest != "" && len(currentOutput) > 0 {
-				if detail, exists := result.TestDetails[currentTest]; exists {
-					detail.Output = strings.Join(currentOutput, "\n")
+			startTest(matches[1])
+			continue
+		}
+		
+		// 检查 t.Attr 设置的属性
+		if matches := attrPattern.FindStringSubmatch(trimmed); matches != nil {
+			detail, exists := block.details[matches[1]]
+			if !exists {
+				detail = &TestDetail{Name: matches[1], Status: "running"}
+				block.details[matches[1]] = detail
+			}
+			detail.setAttribute(matches[2], matches[3])
+			continue
+		}
+		
+		// 检查并行测试暂停，之后的输出属于仍在运行的父测试
+		if matches := pausePattern.FindStringSubmatch(trimmed); matches != nil {
+			currentTest = ""
+			block.endTrailing()
+			if idx := strings.LastIndex(matches[1], "/"); idx > 0 {
+				if parent, exists := block.details[matches[1][:idx]]; exists && parent.Status == "running" {
+					currentTest = parent.Name
 				}
 			}
-			
+			continue
+		}
+		
+		// 检查并行测试恢复或输出切换
+		if matches := contPattern.FindStringSubmatch(trimmed); matches != nil {
 			currentTest = matches[1]
-			currentOutput = make([]string, 0)
-			
-			// 创建测试详情
-			result.TestDetails[currentTest] = &TestDetail{
-				Status: "running",
-				Output: "",
+			block.endTrailing()
+			if _, exists := block.details[currentTest]; !exists && currentTest != "" {
+				// 日志截断在测试中途时可能看不到对应的 === RUN
+				block.details[currentTest] = &TestDetail{
+					Name:   currentTest,
+					Status: "running",
+				}
 			}
 			continue
 		}
 		
 		// 检查测试通过
 		if matches := passPattern.FindStringSubmatch(trimmed); matches != nil {
-			testName := matches[1]
+			testName := benchmarkName(matches[1])
 			elapsed, _ := strconv.ParseFloat(matches[2], 64)
 			
-			result.PassedTests++
-			result.PassedTestNames = append(result.PassedTestNames, testName)
-			
-			if detail, exists := result.TestDetails[testName]; exists {
-				detail.Status = "pass"
+			// 结果行已记录通过的基准测试不重复计数
+			if detail, exists := block.details[testName]; exists && detail.Status == "pass" && testKind(testName) == KindBenchmark {
 				detail.Elapsed = elapsed
-				detail.Output = strings.Join(currentOutput, "\n")
-			} else {
-				result.TestDetails[testName] = &TestDetail{
-					Status:  "pass",
-					Elapsed: elapsed,
-					Output:  strings.Join(currentOutput, "\n"),
-				}
+				continue
 			}
-			currentTest = ""
-			currentOutput = make([]string, 0)
+			
+			result.PassedTests++
+			finishTest(testName, "pass", elapsed)
 			continue
 		}
 		
-		// 检查测试失败
+		// 检查测试失败，模糊测试失败时会再打印一行缩进的同名 --- FAIL，作为尾随输出而不重复计数
 		if matches := failPattern.FindStringSubmatch(trimmed); matches != nil {
-			testName := matches[1]
+			if detail, exists := block.details[matches[1]]; exists && detail.Status == "fail" && line[0] != '-' {
+				block.appendTrailing(line, trimmed)
+				continue
+			}
+			
 			elapsed, _ := strconv.ParseFloat(matches[2], 64)
 			
 			result.FailedTests++
-			result.FailedTestNames = append(result.FailedTestNames, testName)
-			
-			output := strings.Join(currentOutput, "\n")
-			errorMsg := extractErrorFromOutput(output)
-			
-			if detail, exists := result.TestDetails[testName]; exists {
-				detail.Status = "fail"
-				detail.Elapsed = elapsed
-				detail.Output = output
-				detail.Error = errorMsg
-			} else {
-				result.TestDetails[testName] = &TestDetail{
-					Status:  "fail",
-					Elapsed: elapsed,
-					Output:  output,
-					Error:   errorMsg,
-				}
-			}
-			currentTest = ""
-			currentOutput = make([]string, 0)
+			finishTest(benchmarkName(matches[1]), "fail", elapsed)
 			continue
 		}
 		
 		// 检查测试跳过
 		if matches := skipPattern.FindStringSubmatch(trimmed); matches != nil {
-			testName := matches[1]
 			elapsed, _ := strconv.ParseFloat(matches[2], 64)
 			
 			result.SkippedTests++
-			result.SkippedTestNames = append(result.SkippedTestNames, testName)
-			
-			if detail, exists := result.TestDetails[testName]; exists {
-				detail.Status = "skip"
-				detail.Elapsed = elapsed
-				detail.Output = strings.Join(currentOutput, "\n")
-			} else {
-				result.TestDetails[testName] = &TestDetail{
-					Status:  "skip",
-					Elapsed: elapsed,
-					Output:  strings.Join(currentOutput, "\n"),
-				}
-			}
-			currentTest = ""
-			currentOutput = make([]string, 0)
+			finishTest(benchmarkName(matches[1]), "skip", elapsed)
+			continue
+		}
+		
+		// 检查基准测试的 b.Log 输出标题
+		if matches := benchPattern.FindStringSubmatch(trimmed); matches != nil {
+			elapsed, _ := strconv.ParseFloat(matches[2], 64)
+			finishBenchmark(benchmarkName(matches[1]), elapsed)
 			continue
 		}
 		
 		// 检查包测试成功
 		if matches := okPattern.FindStringSubmatch(trimmed); matches != nil {
-			packageName := matches[2]
-			if !packageSet[packageName] {
-				packageSet[packageName] = true
-				result.Packages = append(result.Packages, packageName)
+			if matches[5] != "" {
+				coverage.record(parseCoverage(matches[2], matches[5]))
+			} else if pendingCoverage != nil {
+				pendingCoverage.Package = matches[2]
+				coverage.record(pendingCoverage)
 			}
+			pendingCoverage = nil
+			elapsed, _ := strconv.ParseFloat(matches[4], 64)
+			recordPackage(matches[2], PackagePass, elapsed).Cached = matches[3] != ""
+			continue
+		}
+		
+		// 检查没有测试文件的包
+		if matches := noTestFilesPattern.FindStringSubmatch(trimmed); matches != nil {
+			recordPackage(matches[1], PackageNoTestFiles, 0)
+			continue
+		}
+		
+		// 检查单独的覆盖率行
+		if strings.HasPrefix(trimmed, "coverage: ") {
+			if parsed := parseCoverage("", trimmed); parsed != nil {
+				pendingCoverage = parsed
+				continue
+			}
+		}
+		
+		// 检查没有测试文件的包的覆盖率行
+		if parsed := parseNoTestFilesCoverage(trimmed); parsed != nil && currentTest == "" {
+			coverage.record(parsed)
+			recordPackage(parsed.Package, PackageNoTestFiles, 0)
 			continue
 		}
 		
 		// 检查包测试失败
 		if matches := failPackagePattern.FindStringSubmatch(trimmed); matches != nil {
-			packageName := matches[1]
-			if !packageSet[packageName] {
-				packageSet[packageName] = true
-				result.Packages = append(result.Packages, packageName)
+			status := PackageFail
+			switch matches[3] {
+			case "build":
+				status = PackageBuildFailed
+			case "setup":
+				status = PackageSetupFailed
 			}
+			if matches[2] != "" {
+				diagnostics.assignPackage(matches[1])
+			}
+			if pendingCoverage != nil {
+				pendingCoverage.Package = matches[1]
+				coverage.record(pendingCoverage)
+			}
+			pendingCoverage = nil
+			elapsed, _ := strconv.ParseFloat(matches[4], 64)
+			recordPackage(matches[1], status, elapsed)
+			continue
+		}
+	
+		// 检查是否只是 "FAIL" 或 "PASS" 行
+		if trimmed == "FAIL" || trimmed == "PASS" {
+			block.endTrailing()
 			continue
 		}
 		
-		// 检查编译错误
-		if matches := buildErrorPattern.FindStringSubmatch(trimmed); matches != nil {
-			buildErrors = append(buildErrors, trimmed)
+		// 检查测试超时，其后直到包汇总行的内容都属于超时转储
+		if isTimeoutStart(trimmed) || block.timeoutLines.len() > 0 {
+			block.timeoutLines.append(line)
 			continue
 		}
-
-		// 检查是否只是 "FAIL" 行
-		if trimmed == "FAIL" {
+		
+		// 检查基准测试结果及 goos/goarch/pkg/cpu 头部
+		// -v 模式下基准测试开始时单独打印名称行，相当于 === RUN
+		if benchmark, ok := benchmarks.processLine(line); ok {
+			if benchmark != nil {
+				block.benchmarks = append(block.benchmarks, *benchmark)
+				finishBenchmark(benchmark.Name, 0)
+			} else if matches := benchmarkNamePattern.FindStringSubmatch(line); matches != nil {
+				if detail, exists := block.details[matches[1]]; !exists || detail.Status != "running" {
+					startTest(matches[1])
+				}
+				currentTest = matches[1]
+			}
 			continue
 		}
 		
 		// 收集当前测试的输出
 		if currentTest != "" {
-			currentOutput = append(currentOutput, line)
+			block.outputs.append(currentTest, line)
+			emitter.emit(Event{Type: EventOutput, Test: currentTest, Output: line})
+			continue
+		}
+		
+		// 非 -v 模式下模糊测试的进度输出没有 === RUN 行，暂存到下一个结束的模糊测试
+		if isFuzzLine(trimmed) {
+			block.fuzzLines.append(line)
+			emitter.emit(Event{Type: EventOutput, Output: line})
+			continue
+		}
+		if block.appendTrailing(line, trimmed) {
+			emitter.emit(Event{Type: EventOutput, Test: block.trailingTest, Output: line})
+		} else {
+			block.packageOutput.append(line)
+			emitter.emit(Event{Type: EventOutput, Output: line})
 		}
 	}
 	
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading test log: %w", err)
 	}
-	
-	// 处理最后一个测试的输出
-	if currentTest != "" && len(currentOutput) > 0 {
-		if detail, exists := result.TestDetails[currentTest]; exists {
-			detail.Output = strings.Join(currentOutput, "\n")
-		}
+	if emitter.err != nil {
+		return nil, emitter.err
 	}
 	
-	// 如果有编译错误，创建一个特殊的失败测试
-	if len(buildErrors) > 0 {
-		buildErrorTest := "BuildError"
-		result.FailedTests++
-		result.FailedTestNames = append(result.FailedTestNames, buildErrorTest)
-		result.TestDetails[buildErrorTest] = &TestDetail{
-			Status: "fail",
-			Output: strings.Join(buildErrors, "\n"),
-			Error:  "Build failed",
-		}
-	}
+	// 没有包汇总行的测试保持包名为空
+	block.flush(result, "", PackageUnknown)
+	
+	coverage.apply(result)
+	packages.apply(result)
+	
+	// 如果有编译错误，记录诊断并创建一个特殊的失败测试
+	diagnostics.apply(result)
+	collectRaces(result)
+	
+	buildSubtestTree(result)
 	
 	// 计算总测试数
-	result.TotalTests = result.PassedTests + result.FailedTests + result.SkippedTests
+	result.TotalTests = result.PassedTests + result.FailedTests + result.SkippedTests + result.TimedOutTests + result.IncompleteTests
 	
 	return result, nil
 }
 
 // ValidateTestLog 验证测试日志格式
 func ValidateTestLog(reader io.Reader) error {
-	scanner := bufio.NewScanner(reader)
+	scanner := newLineReader(reader, DefaultMaxLineBytes)
 	lineCount := 0
 	validLines := 0
 	
@@ -409,6 +1210,10 @@ func ValidateTestLog(reader io.Reader) error {
 		var event TestEvent
 		if err := json.Unmarshal([]byte(line), &event); err == nil {
 			validLines++
+		} else if scanner.Truncated() > 0 {
+			if _, ok := decodeTruncatedEvent(line, scanner.Truncated()); ok {
+				validLines++
+			}
 		}
 		
 		// 只检查前100行来判断格式
@@ -435,7 +1240,7 @@ func ValidateTestLog(reader io.Reader) error {
 
 // ValidateTestTextLog 验证文本格式测试日志
 func ValidateTestTextLog(reader io.Reader) error {
-	scanner := bufio.NewScanner(reader)
+	scanner := newLineReader(reader, DefaultMaxLineBytes)
 	lineCount := 0
 	testLines := 0
 	
@@ -37,22 +37,22 @@ func TestParseTestLog_ValidInput(t *testing.T) {
 	if result.FailedTests != 1 {
 		t.Errorf("Expected FailedTests=1, got %d", result.FailedTests)
 	}
-	if len(result.PassedTestNames) != 1 || result.PassedTestNames[0] != "TestExample1" {
-		t.Errorf("Expected PassedTestNames=[TestExample1], got %v", result.PassedTestNames)
+	if len(result.PassedTestNames) != 1 || result.PassedTestNames[0] != "example/pkg.TestExample1" {
+		t.Errorf("Expected PassedTestNames=[example/pkg.TestExample1], got %v", result.PassedTestNames)
 	}
-	if len(result.FailedTestNames) != 1 || result.FailedTestNames[0] != "TestExample2" {
-		t.Errorf("Expected FailedTestNames=[TestExample2], got %v", result.FailedTestNames)
+	if len(result.FailedTestNames) != 1 || result.FailedTestNames[0] != "example/pkg.TestExample2" {
+		t.Errorf("Expected FailedTestNames=[example/pkg.TestExample2], got %v", result.FailedTestNames)
 	}
 	if len(result.Packages) != 1 || result.Packages[0] != "example/pkg" {
 		t.Errorf("Expected Packages=[example/pkg], got %v", result.Packages)
 	}
 	// 验证测试详情
-	if detail, exists := result.TestDetails["TestExample1"]; !exists {
+	if detail, exists := result.TestDetails["example/pkg.TestExample1"]; !exists {
 		t.Error("Expected TestExample1 in TestDetails")
 	} else if detail.Status != "pass" {
 		t.Errorf("Expected status=pass, got %s", detail.Status)
 	}
-	if detail, exists := result.TestDetails["TestExample2"]; !exists {
+	if detail, exists := result.TestDetails["example/pkg.TestExample2"]; !exists {
 		t.Error("Expected TestExample2 in TestDetails")
 	} else if detail.Status != "fail" {
 		t.Errorf("Expected status=fail, got %s", detail.Status)
@@ -103,10 +103,10 @@ func TestParseTestLog_SkippedTests(t *testing.T) {
 	if result.SkippedTests != 1 {
 		t.Errorf("Expected SkippedTests=1, got %d", result.SkippedTests)
 	}
-	if len(result.SkippedTestNames) != 1 || result.SkippedTestNames[0] != "TestSkipped" {
-		t.Errorf("Expected SkippedTestNames=[TestSkipped], got %v", result.SkippedTestNames)
+	if len(result.SkippedTestNames) != 1 || result.SkippedTestNames[0] != "example/pkg.TestSkipped" {
+		t.Errorf("Expected SkippedTestNames=[example/pkg.TestSkipped], got %v", result.SkippedTestNames)
 	}
-	if detail, exists := result.TestDetails["TestSkipped"]; !exists {
+	if detail, exists := result.TestDetails["example/pkg.TestSkipped"]; !exists {
 		t.Error("Expected TestSkipped in TestDetails")
 	} else if detail.Status != "skip" {
 		t.Errorf("Expected status=skip, got %s", detail.Status)
@@ -276,7 +276,7 @@ func TestTestEvent_TimeHandling(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-	if detail, exists := result.TestDetails["TestTime"]; !exists {
+	if detail, exists := result.TestDetails["example/pkg.TestTime"]; !exists {
 		t.Error("Expected TestTime in TestDetails")
 	} else {
 		if detail.Elapsed != 1.864 {
@@ -350,22 +350,22 @@ ok      example/pkg     0.001s`
 	if result.FailedTests != 1 {
 		t.Errorf("Expected FailedTests=1, got %d", result.FailedTests)
 	}
-	if len(result.PassedTestNames) != 1 || result.PassedTestNames[0] != "TestExample1" {
-		t.Errorf("Expected PassedTestNames=[TestExample1], got %v", result.PassedTestNames)
+	if len(result.PassedTestNames) != 1 || result.PassedTestNames[0] != "example/pkg.TestExample1" {
+		t.Errorf("Expected PassedTestNames=[example/pkg.TestExample1], got %v", result.PassedTestNames)
 	}
-	if len(result.FailedTestNames) != 1 || result.FailedTestNames[0] != "TestExample2" {
-		t.Errorf("Expected FailedTestNames=[TestExample2], got %v", result.FailedTestNames)
+	if len(result.FailedTestNames) != 1 || result.FailedTestNames[0] != "example/pkg.TestExample2" {
+		t.Errorf("Expected FailedTestNames=[example/pkg.TestExample2], got %v", result.FailedTestNames)
 	}
 	if len(result.Packages) != 1 || result.Packages[0] != "example/pkg" {
 		t.Errorf("Expected Packages=[example/pkg], got %v", result.Packages)
 	}
 	// 验证测试详情
-	if detail, exists := result.TestDetails["TestExample1"]; !exists {
+	if detail, exists := result.TestDetails["example/pkg.TestExample1"]; !exists {
 		t.Error("Expected TestExample1 in TestDetails")
 	} else if detail.Status != "pass" {
 		t.Errorf("Expected status=pass, got %s", detail.Status)
 	}
-	if detail, exists := result.TestDetails["TestExample2"]; !exists {
+	if detail, exists := result.TestDetails["example/pkg.TestExample2"]; !exists {
 		t.Error("Expected TestExample2 in TestDetails")
 	} else if detail.Status != "fail" {
 		t.Errorf("Expected status=fail, got %s", detail.Status)
@@ -510,8 +510,8 @@ ok      example/pkg     0.001s`
 	if result.SkippedTests != 1 {
 		t.Errorf("Expected SkippedTests=1, got %d", result.SkippedTests)
 	}
-	if len(result.SkippedTestNames) != 1 || result.SkippedTestNames[0] != "TestSkipped" {
-		t.Errorf("Expected SkippedTestNames=[TestSkipped], got %v", result.SkippedTestNames)
+	if len(result.SkippedTestNames) != 1 || result.SkippedTestNames[0] != "example/pkg.TestSkipped" {
+		t.Errorf("Expected SkippedTestNames=[example/pkg.TestSkipped], got %v", result.SkippedTestNames)
 	}
 }
 
@@ -582,4 +582,221 @@ ok      example/pkg     1.001s`
 	if result.PassedTests != 2 {
 		t.Errorf("Expected PassedTests=2, got %d", result.PassedTests)
 	}
-}
\ No newline at end of file
+}
+// TestParseTestLog_SameTestNameInMultiplePackages 测试不同包中的同名测试
+func TestParseTestLog_SameTestNameInMultiplePackages(t *testing.T) {
+	// Arrange
+	testInput := `{"Time":"2024-01-15T10:30:00Z","Action":"run","Package":"pkg1","Test":"TestNew"}
+{"Time":"2024-01-15T10:30:01Z","Action":"fail","Package":"pkg1","Test":"TestNew","Elapsed":0.001}
+{"Time":"2024-01-15T10:30:02Z","Action":"run","Package":"pkg2","Test":"TestNew"}
+{"Time":"2024-01-15T10:30:03Z","Action":"pass","Package":"pkg2","Test":"TestNew","Elapsed":0.002}`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.TestDetails) != 2 {
+		t.Fatalf("Expected 2 entries in TestDetails, got %d", len(result.TestDetails))
+	}
+	if detail := result.TestDetails["pkg1.TestNew"]; detail == nil || detail.Status != "fail" || detail.Package != "pkg1" || detail.Name != "TestNew" {
+		t.Errorf("Expected failed pkg1.TestNew, got %+v", detail)
+	}
+	if detail := result.TestDetails["pkg2.TestNew"]; detail == nil || detail.Status != "pass" || detail.Package != "pkg2" {
+		t.Errorf("Expected passed pkg2.TestNew, got %+v", detail)
+	}
+	if len(result.FailedTestNames) != 1 || result.FailedTestNames[0] != "pkg1.TestNew" {
+		t.Errorf("Expected FailedTestNames=[pkg1.TestNew], got %v", result.FailedTestNames)
+	}
+}
+
+// TestParseTestTextLog_SameTestNameInMultiplePackages 测试文本格式中不同包的同名测试
+func TestParseTestTextLog_SameTestNameInMultiplePackages(t *testing.T) {
+	// Arrange
+	testInput := `=== RUN   TestNew
+    new_test.go:12: unexpected nil
+--- FAIL: TestNew (0.00s)
+FAIL
+FAIL    example/pkg1     0.002s
+=== RUN   TestNew
+--- PASS: TestNew (0.00s)
+PASS
+ok      example/pkg2     0.001s`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.TotalTests != 2 {
+		t.Errorf("Expected TotalTests=2, got %d", result.TotalTests)
+	}
+	if detail := result.TestDetails["example/pkg1.TestNew"]; detail == nil || detail.Status != "fail" {
+		t.Errorf("Expected failed example/pkg1.TestNew, got %+v", detail)
+	} else if !strings.Contains(detail.Output, "unexpected nil") {
+		t.Errorf("Expected output of example/pkg1.TestNew, got %q", detail.Output)
+	}
+	if detail := result.TestDetails["example/pkg2.TestNew"]; detail == nil || detail.Status != "pass" {
+		t.Errorf("Expected passed example/pkg2.TestNew, got %+v", detail)
+	}
+	if len(result.FailedTestNames) != 1 || result.FailedTestNames[0] != "example/pkg1.TestNew" {
+		t.Errorf("Expected FailedTestNames=[example/pkg1.TestNew], got %v", result.FailedTestNames)
+	}
+}
+
+// TestTestResult_LookupTest 测试按包名和测试名查找测试详情
+func TestTestResult_LookupTest(t *testing.T) {
+	// Arrange
+	result := &TestResult{
+		TestDetails: map[string]*TestDetail{
+			"pkg1.TestNew":  {Package: "pkg1", Name: "TestNew", Status: "fail"},
+			"pkg2.TestNew":  {Package: "pkg2", Name: "TestNew", Status: "pass"},
+			"pkg2.TestOnly": {Package: "pkg2", Name: "TestOnly", Status: "pass"},
+		},
+	}
+
+	// Act & Assert
+	if detail, err := result.LookupTest("pkg1", "TestNew"); err != nil || detail.Status != "fail" {
+		t.Errorf("Expected pkg1.TestNew to be found, got %+v, %v", detail, err)
+	}
+	if detail, err := result.LookupTest("", "TestOnly"); err != nil || detail.Package != "pkg2" {
+		t.Errorf("Expected unique TestOnly to be found, got %+v, %v", detail, err)
+	}
+	if detail, err := result.LookupTest("", "pkg2.TestNew"); err != nil || detail.Package != "pkg2" {
+		t.Errorf("Expected qualified key to be found, got %+v, %v", detail, err)
+	}
+	if _, err := result.LookupTest("", "TestNew"); err == nil || !strings.Contains(err.Error(), "ambiguous") ||
+		!strings.Contains(err.Error(), "pkg1, pkg2") {
+		t.Errorf("Expected ambiguity error listing packages, got %v", err)
+	}
+	if _, err := result.LookupTest("pkg3", "TestNew"); err == nil || !strings.Contains(err.Error(), "test not found") {
+		t.Errorf("Expected 'test not found' error, got %v", err)
+	}
+}
+
+// TestParseTestTextLog_ParallelSubtests 测试并行子测试交错输出时按 === PAUSE/=== NAME 归属
+func TestParseTestTextLog_ParallelSubtests(t *testing.T) {
+	// Arrange
+	testInput := `=== RUN   TestAPI
+=== RUN   TestAPI/create
+=== PAUSE TestAPI/create
+=== RUN   TestAPI/delete
+=== PAUSE TestAPI/delete
+    api_test.go:20: parent setup done
+=== CONT  TestAPI/create
+=== CONT  TestAPI/delete
+    api_test.go:41: delete returned 500
+=== NAME  TestAPI/create
+    api_test.go:30: create ok
+--- PASS: TestAPI/create (0.01s)
+=== NAME  TestAPI/delete
+    api_test.go:42: want 204
+--- FAIL: TestAPI/delete (0.02s)
+=== NAME  TestAPI
+    api_test.go:50: teardown
+--- FAIL: TestAPI (0.02s)
+FAIL
+FAIL	example.com/api	0.030s`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	create := result.TestDetails["example.com/api.TestAPI/create"]
+	if create == nil || create.Status != "pass" || create.Output != "    api_test.go:30: create ok" {
+		t.Errorf("Unexpected TestAPI/create: %+v", create)
+	}
+	del := result.TestDetails["example.com/api.TestAPI/delete"]
+	if del == nil || del.Status != "fail" {
+		t.Fatalf("Unexpected TestAPI/delete: %+v", del)
+	}
+	if !strings.Contains(del.Output, "delete returned 500") || !strings.Contains(del.Output, "want 204") {
+		t.Errorf("Expected both delete lines on TestAPI/delete, got %q", del.Output)
+	}
+	parent := result.TestDetails["example.com/api.TestAPI"]
+	if !strings.Contains(parent.Output, "parent setup done") || !strings.Contains(parent.Output, "teardown") {
+		t.Errorf("Expected parent lines on TestAPI, got %q", parent.Output)
+	}
+	if strings.Contains(parent.Output, "delete returned 500") || strings.Contains(create.Output, "500") {
+		t.Errorf("Subtest output leaked into another test: parent=%q create=%q", parent.Output, create.Output)
+	}
+}
+
+// TestParseTestTextLog_ParallelTopLevel 测试顶层并行测试在 === CONT 之间切换输出
+func TestParseTestTextLog_ParallelTopLevel(t *testing.T) {
+	// Arrange
+	testInput := `=== RUN   TestA
+=== PAUSE TestA
+=== RUN   TestB
+=== PAUSE TestB
+=== CONT  TestA
+=== CONT  TestB
+    b_test.go:9: expected 1, got 2
+=== CONT  TestA
+    a_test.go:5: all good
+--- PASS: TestA (0.10s)
+--- FAIL: TestB (0.10s)
+FAIL
+FAIL	example.com/par	0.101s`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detail := result.TestDetails["example.com/par.TestA"]; detail.Status != "pass" || detail.Output != "    a_test.go:5: all good" {
+		t.Errorf("Unexpected TestA: %+v", detail)
+	}
+	if detail := result.TestDetails["example.com/par.TestB"]; detail.Status != "fail" || !strings.Contains(detail.Error, "expected 1, got 2") {
+		t.Errorf("Unexpected TestB: %+v", detail)
+	}
+}
+
+// TestParseTestLog_PauseContBench 测试 JSON 日志中的 pause/cont/bench 事件
+func TestParseTestLog_PauseContBench(t *testing.T) {
+	// Arrange
+	testInput := `{"Action":"start","Package":"example"}
+{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"pause","Package":"example","Test":"TestA"}
+{"Action":"cont","Package":"example","Test":"TestB"}
+{"Action":"cont","Package":"example","Test":"TestA"}
+{"Action":"pass","Package":"example","Test":"TestA","Elapsed":0}
+{"Action":"run","Package":"example","Test":"BenchmarkX"}
+{"Action":"bench","Package":"example","Test":"BenchmarkX","Output":"BenchmarkX-8   \t 1000\t  1200 ns/op\n"}
+{"Action":"pass","Package":"example","Test":"BenchmarkX","Elapsed":0}
+{"Action":"pass","Package":"example","Elapsed":0.5}`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detail := result.TestDetails["example.TestA"]; detail.Status != "pass" {
+		t.Errorf("Expected TestA to pass after pause/cont, got %+v", detail)
+	}
+	if detail := result.TestDetails["example.TestB"]; detail == nil || detail.Status != "running" {
+		t.Errorf("Expected TestB created from cont to be running, got %+v", detail)
+	}
+	if detail := result.TestDetails["example.BenchmarkX"]; !strings.Contains(detail.Output, "1200 ns/op") {
+		t.Errorf("Expected bench output on BenchmarkX, got %q", detail.Output)
+	}
+	if len(result.Benchmarks) != 1 || result.Benchmarks[0].Iterations != 1000 {
+		t.Errorf("Expected 1 benchmark result, got %+v", result.Benchmarks)
+	}
+}
@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RaceAccess 数据竞争中的一次内存访问
+type RaceAccess struct {
+	Op        string       `json:"op"` // read、write、atomic read 等
+	Address   string       `json:"address"`
+	Goroutine int          `json:"goroutine"`
+	Frames    []StackFrame `json:"frames"`
+}
+
+// RaceGoroutine 参与数据竞争的协程及其创建位置
+type RaceGoroutine struct {
+	ID        int          `json:"id"`
+	State     string       `json:"state"` // running 或 finished
+	CreatedAt []StackFrame `json:"created_at"`
+}
+
+// DataRace 一次 WARNING: DATA RACE 报告
+type DataRace struct {
+	Package        string          `json:"package"`
+	Test           string          `json:"test"`
+	Access         RaceAccess      `json:"access"`
+	PreviousAccess *RaceAccess     `json:"previous_access,omitempty"`
+	Goroutines     []RaceGoroutine `json:"goroutines"`
+	Location       string          `json:"location,omitempty"`
+	FirstUserFrame *StackFrame     `json:"first_user_frame,omitempty"`
+}
+
+var (
+	// Write at 0x00c0000a4010 by goroutine 8: 或 Previous read at 0x... by main goroutine:
+	raceAccessPattern = regexp.MustCompile(`^(Previous )?((?:[Aa]tomic )?(?:[Rr]ead|[Ww]rite)) at (0x[0-9a-f]+) by (?:main goroutine|goroutine (\d+))`)
+	// Goroutine 8 (running) created at:
+	raceGoroutinePattern = regexp.MustCompile(`^Goroutine (\d+) \((\w+)\) created at:$`)
+)
+
+// parseRaces 从测试输出中解析所有数据竞争报告，没有时返回 nil
+func parseRaces(output string) []DataRace {
+	var races []DataRace
+	var current *DataRace
+	var frames *[]StackFrame
+	pendingFunction := ""
+	
+	finish := func() {
+		if current != nil {
+			current.FirstUserFrame = raceUserFrame(current)
+		}
+		current = nil
+		frames = nil
+	}
+	
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(strings.TrimRight(line, "\r"))
+		if trimmed == "WARNING: DATA RACE" {
+			finish()
+			races = append(races, DataRace{Goroutines: make([]RaceGoroutine, 0)})
+			current = &races[len(races)-1]
+			continue
+		}
+		if current == nil || trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "==================") {
+			finish()
+			continue
+		}
+		
+		if matches := raceAccessPattern.FindStringSubmatch(trimmed); matches != nil {
+			// race 检测器用 "main goroutine" 指代 1 号协程
+			id := 1
+			if matches[4] != "" {
+				id, _ = strconv.Atoi(matches[4])
+			}
+			access := RaceAccess{
+				Op:        strings.ToLower(matches[2]),
+				Address:   matches[3],
+				Goroutine: id,
+				Frames:    make([]StackFrame, 0),
+			}
+			if matches[1] != "" {
+				current.PreviousAccess = &access
+				frames = &current.PreviousAccess.Frames
+			} else {
+				current.Access = access
+				frames = &current.Access.Frames
+			}
+			pendingFunction = ""
+			continue
+		}
+		if matches := raceGoroutinePattern.FindStringSubmatch(trimmed); matches != nil {
+			id, _ := strconv.Atoi(matches[1])
+			current.Goroutines = append(current.Goroutines, RaceGoroutine{
+				ID:        id,
+				State:     matches[2],
+				CreatedAt: make([]StackFrame, 0),
+			})
+			frames = &current.Goroutines[len(current.Goroutines)-1].CreatedAt
+			pendingFunction = ""
+			continue
+		}
+		if strings.HasPrefix(trimmed, "Location is ") {
+			current.Location = trimmed
+			frames = nil
+			continue
+		}
+		if strings.HasSuffix(trimmed, "created at:") {
+			// 互斥锁等其他创建位置不属于访问栈
+			frames = nil
+			continue
+		}
+		if frames == nil {
+			continue
+		}
+		
+		// 函数行之后紧跟文件行
+		if matches := frameFilePattern.FindStringSubmatch(trimmed); matches != nil && pendingFunction != "" {
+			lineNum, _ := strconv.Atoi(matches[2])
+			*frames = append(*frames, StackFrame{
+				Function: pendingFunction,
+				File:     matches[1],
+				Line:     lineNum,
+				IsUser:   isUserFunction(pendingFunction) && !strings.HasSuffix(matches[1], "_testmain.go"),
+			})
+			pendingFunction = ""
+			continue
+		}
+		pendingFunction = functionName(trimmed)
+	}
+	finish()
+	
+	return races
+}
+
+// raceUserFrame 返回当前访问栈中第一个属于被测代码的帧，没有时查找之前的访问
+func raceUserFrame(race *DataRace) *StackFrame {
+	stacks := [][]StackFrame{race.Access.Frames}
+	if race.PreviousAccess != nil {
+		stacks = append(stacks, race.PreviousAccess.Frames)
+	}
+	for _, stack := range stacks {
+		for i := range stack {
+			if stack[i].IsUser {
+				frame := stack[i]
+				return &frame
+			}
+		}
+	}
+	return nil
+}
+
+// collectRaces 将各测试中的数据竞争按测试标识排序汇总到结果中
+func collectRaces(result *TestResult) {
+	keys := make([]string, 0)
+	for key, detail := range result.TestDetails {
+		if len(detail.Races) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	
+	for _, key := range keys {
+		detail := result.TestDetails[key]
+		for i := range detail.Races {
+			detail.Races[i].Package = detail.Package
+			detail.Races[i].Test = detail.Name
+			result.Races = append(result.Races, detail.Races[i])
+		}
+	}
+}
+
+// formatAccess 格式化一次访问，如 "write at counter.go:12 by goroutine 8"
+func formatAccess(access RaceAccess) string {
+	text := access.Op
+	for _, frame := range access.Frames {
+		if frame.IsUser {
+			text += fmt.Sprintf(" at %s:%d", frame.File, frame.Line)
+			break
+		}
+	}
+	return text + fmt.Sprintf(" by goroutine %d", access.Goroutine)
+}
+
+// formatRaces 将数据竞争格式化为错误信息
+func formatRaces(races []DataRace) string {
+	lines := make([]string, 0, len(races))
+	for _, race := range races {
+		line := "DATA RACE: " + formatAccess(race.Access)
+		if race.PreviousAccess != nil {
+			line += ", previous " + formatAccess(*race.PreviousAccess)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const raceTextLog = `=== RUN   TestCounter
+==================
+WARNING: DATA RACE
+Write at 0x00c0000a4010 by goroutine 8:
+  example.com/app/counter.(*Counter).Inc()
+      /home/dev/app/counter/counter.go:12 +0x44
+  example.com/app/counter.TestCounter.func1()
+      /home/dev/app/counter/counter_test.go:20 +0x2e
+
+Previous read at 0x00c0000a4010 by goroutine 7:
+  example.com/app/counter.(*Counter).Get()
+      /home/dev/app/counter/counter.go:16 +0x3a
+  example.com/app/counter.TestCounter()
+      /home/dev/app/counter/counter_test.go:24 +0x9c
+  testing.tRunner()
+      /usr/local/go/src/testing/testing.go:1595 +0x238
+
+Goroutine 8 (running) created at:
+  example.com/app/counter.TestCounter()
+      /home/dev/app/counter/counter_test.go:18 +0x8c
+  testing.tRunner()
+      /usr/local/go/src/testing/testing.go:1595 +0x238
+
+Goroutine 7 (running) created at:
+  testing.(*T).Run()
+      /usr/local/go/src/testing/testing.go:1648 +0x82a
+==================
+    testing.go:1465: race detected during execution of test
+--- FAIL: TestCounter (0.00s)
+=== RUN   TestOther
+--- PASS: TestOther (0.00s)
+FAIL
+FAIL	example.com/app/counter	0.021s`
+
+// TestParseRaces 测试解析数据竞争报告的访问栈和协程创建位置
+func TestParseRaces(t *testing.T) {
+	// Act
+	races := parseRaces(raceTextLog)
+
+	// Assert
+	if len(races) != 1 {
+		t.Fatalf("Expected 1 race, got %d", len(races))
+	}
+	race := races[0]
+	if race.Access.Op != "write" || race.Access.Goroutine != 8 || race.Access.Address != "0x00c0000a4010" {
+		t.Errorf("Unexpected access: %+v", race.Access)
+	}
+	if len(race.Access.Frames) != 2 || race.Access.Frames[0].Function != "example.com/app/counter.(*Counter).Inc" {
+		t.Errorf("Unexpected access frames: %+v", race.Access.Frames)
+	}
+	if race.PreviousAccess == nil || race.PreviousAccess.Op != "read" || race.PreviousAccess.Goroutine != 7 {
+		t.Fatalf("Unexpected previous access: %+v", race.PreviousAccess)
+	}
+	if len(race.PreviousAccess.Frames) != 3 || race.PreviousAccess.Frames[2].IsUser {
+		t.Errorf("Expected 3 previous frames ending in testing.tRunner, got %+v", race.PreviousAccess.Frames)
+	}
+	if len(race.Goroutines) != 2 || race.Goroutines[0].ID != 8 || race.Goroutines[0].State != "running" {
+		t.Fatalf("Unexpected goroutines: %+v", race.Goroutines)
+	}
+	if len(race.Goroutines[0].CreatedAt) != 2 || race.Goroutines[0].CreatedAt[0].Line != 18 {
+		t.Errorf("Unexpected creation site: %+v", race.Goroutines[0].CreatedAt)
+	}
+	if race.FirstUserFrame == nil || race.FirstUserFrame.Line != 12 {
+		t.Errorf("Expected first user frame at counter.go:12, got %+v", race.FirstUserFrame)
+	}
+}
+
+// TestParseRaces_MainGoroutine 测试 main goroutine 和原子操作的访问
+func TestParseRaces_MainGoroutine(t *testing.T) {
+	// Arrange
+	output := `WARNING: DATA RACE
+Atomic write at 0x00000060c1a8 by main goroutine:
+  main.main()
+      /home/dev/app/main.go:9 +0x3c
+
+Previous write at 0x00000060c1a8 by goroutine 6:
+  main.main.func1()
+      /home/dev/app/main.go:7 +0x38
+
+Location is global 'counter' of size 8 at 0x00000060c1a8 (main+0x60c1a8)
+==================`
+
+	// Act
+	races := parseRaces(output)
+
+	// Assert
+	if len(races) != 1 {
+		t.Fatalf("Expected 1 race, got %d", len(races))
+	}
+	if races[0].Access.Op != "atomic write" || races[0].Access.Goroutine != 1 {
+		t.Errorf("Expected atomic write by main goroutine, got %+v", races[0].Access)
+	}
+	if !strings.HasPrefix(races[0].Location, "Location is global 'counter'") {
+		t.Errorf("Unexpected location: %q", races[0].Location)
+	}
+}
+
+// TestParseTestTextLog_DataRace 测试数据竞争归属到所在测试并生成错误信息
+func TestParseTestTextLog_DataRace(t *testing.T) {
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(raceTextLog))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Races) != 1 {
+		t.Fatalf("Expected 1 race in result, got %d", len(result.Races))
+	}
+	if result.Races[0].Package != "example.com/app/counter" || result.Races[0].Test != "TestCounter" {
+		t.Errorf("Expected race attributed to example.com/app/counter.TestCounter, got %s.%s", result.Races[0].Package, result.Races[0].Test)
+	}
+	detail := result.TestDetails["example.com/app/counter.TestCounter"]
+	expected := "DATA RACE: write at /home/dev/app/counter/counter.go:12 by goroutine 8, previous read at /home/dev/app/counter/counter.go:16 by goroutine 7"
+	if detail.Error != expected {
+		t.Errorf("Expected error %q, got %q", expected, detail.Error)
+	}
+	if len(result.TestDetails["example.com/app/counter.TestOther"].Races) != 0 {
+		t.Error("Expected no races on TestOther")
+	}
+}
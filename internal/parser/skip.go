@@ -0,0 +1,69 @@
+package parser
+
+import "sort"
+
+// SkipInfo t.Skip/t.Skipf 给出的跳过原因及其位置，t.SkipNow 跳过时原因为空
+type SkipInfo struct {
+	Reason string `json:"reason"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+}
+
+// SkipGroup 按原因分组的跳过测试
+type SkipGroup struct {
+	Reason    string           `json:"reason"` // 空字符串表示没有给出原因
+	Count     int              `json:"count"`
+	Tests     []string         `json:"tests"`
+	Locations []SourceLocation `json:"locations"`
+}
+
+// parseSkip 从跳过测试的日志中提取原因：t.Skip 等同于 t.Log 后调用 SkipNow，原因是最后一条日志
+func parseSkip(logs []LogEntry) *SkipInfo {
+	if len(logs) == 0 {
+		return &SkipInfo{}
+	}
+	last := logs[len(logs)-1]
+	return &SkipInfo{Reason: last.Message, File: last.File, Line: last.Line}
+}
+
+// SkipReasons 按原因分组返回跳过的测试，测试多的分组在前
+func (r *TestResult) SkipReasons() []SkipGroup {
+	groups := make([]SkipGroup, 0)
+	index := make(map[string]int)
+	seen := make(map[string]map[SourceLocation]bool)
+	for _, key := range r.SkippedTestNames {
+		detail, exists := r.TestDetails[key]
+		if !exists || detail.Status != "skip" {
+			continue
+		}
+		skip := detail.Skip
+		if skip == nil {
+			skip = &SkipInfo{}
+		}
+		
+		i, exists := index[skip.Reason]
+		if !exists {
+			i = len(groups)
+			index[skip.Reason] = i
+			groups = append(groups, SkipGroup{
+				Reason:    skip.Reason,
+				Tests:     make([]string, 0),
+				Locations: make([]SourceLocation, 0),
+			})
+			seen[skip.Reason] = make(map[SourceLocation]bool)
+		}
+		group := &groups[i]
+		group.Count++
+		group.Tests = append(group.Tests, key)
+		location := SourceLocation{File: skip.File, Line: skip.Line}
+		if skip.File != "" && !seen[skip.Reason][location] {
+			seen[skip.Reason][location] = true
+			group.Locations = append(group.Locations, location)
+		}
+	}
+	
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Count > groups[j].Count
+	})
+	return groups
+}
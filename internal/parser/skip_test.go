@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_SkipReason 测试从跳过测试的最后一条日志提取原因和位置
+func TestParseTestTextLog_SkipReason(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestDocker
+    docker_test.go:12: checking environment
+    docker_test.go:15: requires docker
+--- SKIP: TestDocker (0.00s)
+=== RUN   TestRedis
+    redis_test.go:8: requires docker
+--- SKIP: TestRedis (0.00s)
+=== RUN   TestSlow
+    slow_test.go:20: short mode
+--- SKIP: TestSlow (0.00s)
+=== RUN   TestNow
+--- SKIP: TestNow (0.00s)
+PASS
+ok  	example.com/app	0.010s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	skip := result.TestDetails["example.com/app.TestDocker"].Skip
+	if skip == nil || skip.Reason != "requires docker" || skip.File != "docker_test.go" || skip.Line != 15 {
+		t.Errorf("Unexpected skip info: %+v", skip)
+	}
+	if skip := result.TestDetails["example.com/app.TestNow"].Skip; skip == nil || skip.Reason != "" {
+		t.Errorf("Expected empty reason for SkipNow, got %+v", skip)
+	}
+	
+	groups := result.SkipReasons()
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 skip groups, got %+v", groups)
+	}
+	if groups[0].Reason != "requires docker" || groups[0].Count != 2 || len(groups[0].Locations) != 2 {
+		t.Errorf("Unexpected first group: %+v", groups[0])
+	}
+	if result.TestDetails["example.com/app.TestDocker"].Status != "skip" {
+		t.Errorf("Expected skipped status to be kept")
+	}
+}
+
+// TestParseTestLog_SkipReason 测试 JSON 日志中的跳过原因
+func TestParseTestLog_SkipReason(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"output","Package":"example","Test":"TestA","Output":"    a_test.go:9: skipping: GOOS=windows\n"}
+{"Action":"output","Package":"example","Test":"TestA","Output":"--- SKIP: TestA (0.00s)\n"}
+{"Action":"skip","Package":"example","Test":"TestA","Elapsed":0}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if skip := result.TestDetails["example.TestA"].Skip; skip == nil || skip.Reason != "skipping: GOOS=windows" || skip.Line != 9 {
+		t.Errorf("Unexpected skip info: %+v", skip)
+	}
+}
@@ -0,0 +1,63 @@
+package parser
+
+// EventType 流式解析事件类型
+type EventType string
+
+// 流式解析事件类型
+const (
+	EventTestStarted EventType = "test_started"
+	EventOutput      EventType = "output" // Test 为空时为包级输出
+	EventTestPassed  EventType = "test_passed"
+	EventTestFailed  EventType = "test_failed"
+	EventTestSkipped EventType = "test_skipped"
+	EventPackageDone EventType = "package_done"
+)
+
+// Event 流式解析过程中按读取顺序产生的事件
+type Event struct {
+	Type EventType `json:"type"`
+	// 文本日志中包名要到 ok/FAIL 汇总行才出现，package_done 之前的测试事件包名为空
+	Package string  `json:"package"`
+	Test    string  `json:"test,omitempty"`
+	Output  string  `json:"output,omitempty"`
+	Elapsed float64 `json:"elapsed,omitempty"`
+	Status  string  `json:"status,omitempty"` // package_done 事件的包状态
+
+	// 测试结束事件对应的详情，已根据当前收到的输出整理出错误信息；
+	// 测试结束后才到达的输出会在解析结束时更新到同一个详情中
+	Detail *TestDetail `json:"-"`
+}
+
+// EventHandler 处理流式事件，返回错误时停止解析，解析函数原样返回该错误
+type EventHandler func(Event) error
+
+// finishEventType 返回测试结束状态对应的事件类型
+func finishEventType(status string) EventType {
+	switch status {
+	case "pass":
+		return EventTestPassed
+	case "fail":
+		return EventTestFailed
+	default:
+		return EventTestSkipped
+	}
+}
+
+// eventEmitter 将事件交给处理函数，并记录处理函数要求停止时返回的错误
+type eventEmitter struct {
+	handler EventHandler
+	err     error
+}
+
+// emit 发送事件，已停止或没有处理函数时忽略
+func (e *eventEmitter) emit(event Event) {
+	if e.handler == nil || e.err != nil {
+		return
+	}
+	e.err = e.handler(event)
+}
+
+// active 判断是否需要构造事件
+func (e *eventEmitter) active() bool {
+	return e.handler != nil && e.err == nil
+}
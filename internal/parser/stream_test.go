@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestStreamTestLog_Events 测试 JSON 日志按读取顺序产生事件，汇总结果与 ParseTestLog 一致
+func TestStreamTestLog_Events(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"output","Package":"example","Test":"TestA","Output":"    a_test.go:3: boom\n"}
+{"Action":"fail","Package":"example","Test":"TestA","Elapsed":0.1}
+{"Action":"run","Package":"example","Test":"TestB"}
+{"Action":"skip","Package":"example","Test":"TestB","Elapsed":0}
+{"Action":"fail","Package":"example","Elapsed":0.2}`
+	var events []Event
+
+	// Act
+	result, err := StreamTestLog(strings.NewReader(log), func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		types = append(types, string(event.Type))
+	}
+	expected := "test_started,output,test_failed,test_started,test_skipped,package_done"
+	if strings.Join(types, ",") != expected {
+		t.Errorf("Expected events %s, got %s", expected, strings.Join(types, ","))
+	}
+	if events[2].Detail == nil || !strings.Contains(events[2].Detail.Error, "boom") {
+		t.Errorf("Expected failed event to carry the detail with error, got %+v", events[2].Detail)
+	}
+	if events[5].Status != PackageFail || events[5].Elapsed != 0.2 {
+		t.Errorf("Unexpected package_done event: %+v", events[5])
+	}
+	if result.FailedTests != 1 || result.SkippedTests != 1 {
+		t.Errorf("Unexpected aggregate result: %+v", result)
+	}
+}
+
+// TestStreamTestTextLog_StopOnFirstFailure 测试处理函数返回错误时停止读取并返回该错误
+func TestStreamTestTextLog_StopOnFirstFailure(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestA
+    a_test.go:3: expected 1, got 2
+--- FAIL: TestA (0.00s)
+=== RUN   TestB
+--- PASS: TestB (0.00s)
+FAIL
+FAIL	example.com/app	0.010s`
+	errStop := errors.New("stop")
+	var failed *Event
+	seen := 0
+
+	// Act
+	result, err := StreamTestTextLog(strings.NewReader(log), func(event Event) error {
+		seen++
+		if event.Type == EventTestFailed {
+			failed = &event
+			return errStop
+		}
+		return nil
+	})
+
+	// Assert
+	if !errors.Is(err, errStop) || result != nil {
+		t.Fatalf("Expected stop error and nil result, got %v, %v", result, err)
+	}
+	if failed == nil || failed.Test != "TestA" || !strings.Contains(failed.Detail.Error, "expected 1, got 2") {
+		t.Errorf("Unexpected failed event: %+v", failed)
+	}
+	if seen != 3 {
+		t.Errorf("Expected parsing to stop after 3 events, got %d", seen)
+	}
+}
+
+// TestStreamTestTextLog_PackageDone 测试文本日志的包汇总行产生 package_done 事件，测试详情此时已带包名
+func TestStreamTestTextLog_PackageDone(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestA
+--- PASS: TestA (0.00s)
+PASS
+ok  	example.com/app	0.010s`
+	var passed, done *Event
+
+	// Act
+	_, err := StreamTestTextLog(strings.NewReader(log), func(event Event) error {
+		switch event.Type {
+		case EventTestPassed:
+			passed = &event
+		case EventPackageDone:
+			done = &event
+		}
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if done == nil || done.Package != "example.com/app" || done.Status != PackagePass {
+		t.Fatalf("Unexpected package_done event: %+v", done)
+	}
+	if passed == nil || passed.Package != "" || passed.Detail.Package != "example.com/app" {
+		t.Errorf("Expected detail package to be filled in once the package is done, got %+v", passed)
+	}
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SubtestCounts 子测试统计，包含所有后代测试
+type SubtestCounts struct {
+	Total      int `json:"total"`
+	Passed     int `json:"passed"`
+	Failed     int `json:"failed"`
+	Skipped    int `json:"skipped"`
+	TimedOut   int `json:"timed_out"`
+	Incomplete int `json:"incomplete"`
+}
+
+// buildSubtestTree 根据 t.Run 产生的 "Parent/child" 名称建立父子关系并汇总子测试统计
+func buildSubtestTree(result *TestResult) {
+	for key, detail := range result.TestDetails {
+		parentKey := findParentKey(result, detail)
+		if parentKey == "" {
+			continue
+		}
+		
+		detail.Parent = parentKey
+		parent := result.TestDetails[parentKey]
+		parent.Children = append(parent.Children, key)
+	}
+	
+	for _, detail := range result.TestDetails {
+		sort.Strings(detail.Children)
+		
+		// 将当前测试的状态累加到所有祖先
+		for parentKey := detail.Parent; parentKey != ""; parentKey = result.TestDetails[parentKey].Parent {
+			parent := result.TestDetails[parentKey]
+			if parent.Subtests == nil {
+				parent.Subtests = &SubtestCounts{}
+			}
+			parent.Subtests.Total++
+			switch detail.Status {
+			case "pass":
+				parent.Subtests.Passed++
+			case "fail":
+				parent.Subtests.Failed++
+			case "skip":
+				parent.Subtests.Skipped++
+			case "timeout":
+				parent.Subtests.TimedOut++
+			case "incomplete":
+				parent.Subtests.Incomplete++
+			}
+		}
+	}
+	
+	// 只因子测试失败而失败的测试自身没有错误日志，错误信息改为失败子测试的摘要
+	for _, detail := range result.TestDetails {
+		if detail.Status == "fail" && detail.Subtests != nil && !detail.hasOwnError() {
+			if summary := summarizeFailedSubtests(result, detail); summary != "" {
+				detail.Error = summary
+			}
+		}
+	}
+}
+
+// hasOwnError 判断失败的测试是否有确知属于自身的错误：panic、testify 断言、数据竞争、Example 不匹配或错误日志
+func (d *TestDetail) hasOwnError() bool {
+	return d.Panic != nil || len(d.Assertions) > 0 || len(d.Races) > 0 || d.Example != nil || len(d.ErrorLogs()) > 0
+}
+
+// summarizeFailedSubtests 列出测试下失败的子测试及各自错误信息的首行，中间层只因子测试失败的不重复列出
+func summarizeFailedSubtests(result *TestResult, detail *TestDetail) string {
+	lines := make([]string, 0)
+	for _, failed := range result.FailedDescendants(detail) {
+		if failed.Subtests != nil && (failed.Subtests.Failed > 0 || failed.Subtests.TimedOut > 0) && !failed.hasOwnError() {
+			continue
+		}
+		line := failed.Name
+		if first, _, _ := strings.Cut(failed.Error, "\n"); first != "" {
+			line += ": " + first
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d subtest(s) failed:\n%s", len(lines), strings.Join(lines, "\n"))
+}
+
+// findParentKey 查找最近的已存在祖先测试，名称中每个 "/" 表示一层子测试
+func findParentKey(result *TestResult, detail *TestDetail) string {
+	name := detail.Name
+	for {
+		idx := strings.LastIndex(name, "/")
+		if idx <= 0 {
+			return ""
+		}
+		name = name[:idx]
+		
+		parentKey := TestKey(detail.Package, name)
+		if _, exists := result.TestDetails[parentKey]; exists {
+			return parentKey
+		}
+	}
+}
+
+// FailedDescendants 按深度优先顺序返回测试的所有失败（含超时）后代
+func (r *TestResult) FailedDescendants(detail *TestDetail) []*TestDetail {
+	failed := make([]*TestDetail, 0)
+	for _, childKey := range detail.Children {
+		child, exists := r.TestDetails[childKey]
+		if !exists {
+			continue
+		}
+		if child.Status == "fail" || child.Status == "timeout" {
+			failed = append(failed, child)
+		}
+		failed = append(failed, r.FailedDescendants(child)...)
+	}
+	return failed
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_SubtestTree 测试文本格式子测试层级的建立
+func TestParseTestTextLog_SubtestTree(t *testing.T) {
+	// Arrange
+	testInput := `=== RUN   TestParse
+=== RUN   TestParse/empty_input
+=== RUN   TestParse/empty_input/utf16
+    parse_test.go:42: expected BOM, got none
+=== RUN   TestParse/empty_input/utf8
+=== RUN   TestParse/simple
+--- FAIL: TestParse (0.00s)
+    --- FAIL: TestParse/empty_input (0.00s)
+        --- FAIL: TestParse/empty_input/utf16 (0.00s)
+        --- PASS: TestParse/empty_input/utf8 (0.00s)
+    --- SKIP: TestParse/simple (0.00s)
+FAIL
+FAIL    example/pkg     0.002s`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	root := result.TestDetails["example/pkg.TestParse"]
+	if root == nil {
+		t.Fatal("Expected TestParse in TestDetails")
+	}
+	if root.Parent != "" {
+		t.Errorf("Expected no parent for TestParse, got %s", root.Parent)
+	}
+	expectedChildren := []string{"example/pkg.TestParse/empty_input", "example/pkg.TestParse/simple"}
+	if strings.Join(root.Children, ",") != strings.Join(expectedChildren, ",") {
+		t.Errorf("Expected children %v, got %v", expectedChildren, root.Children)
+	}
+	if root.Subtests == nil || root.Subtests.Total != 4 || root.Subtests.Failed != 2 ||
+		root.Subtests.Passed != 1 || root.Subtests.Skipped != 1 {
+		t.Errorf("Expected subtests {4 1 2 1}, got %+v", root.Subtests)
+	}
+
+	leaf := result.TestDetails["example/pkg.TestParse/empty_input/utf16"]
+	if leaf == nil {
+		t.Fatal("Expected leaf subtest in TestDetails")
+	}
+	if leaf.Parent != "example/pkg.TestParse/empty_input" {
+		t.Errorf("Expected parent example/pkg.TestParse/empty_input, got %s", leaf.Parent)
+	}
+	if !strings.Contains(leaf.Output, "expected BOM, got none") {
+		t.Errorf("Expected leaf output to contain assertion text, got %q", leaf.Output)
+	}
+	if strings.Contains(root.Output, "expected BOM") {
+		t.Errorf("Expected parent output not to contain subtest output, got %q", root.Output)
+	}
+
+	failed := result.FailedDescendants(root)
+	if len(failed) != 2 || failed[0].Name != "TestParse/empty_input" || failed[1].Name != "TestParse/empty_input/utf16" {
+		t.Errorf("Expected failed descendants [empty_input, empty_input/utf16], got %d entries", len(failed))
+	}
+}
+
+// TestParseTestLog_SubtestTree 测试JSON格式子测试层级的建立
+func TestParseTestLog_SubtestTree(t *testing.T) {
+	// Arrange
+	testInput := `{"Action":"run","Package":"example/pkg","Test":"TestParse"}
+{"Action":"run","Package":"example/pkg","Test":"TestParse/a"}
+{"Action":"output","Package":"example/pkg","Test":"TestParse/a","Output":"    parse_test.go:10: boom\n"}
+{"Action":"fail","Package":"example/pkg","Test":"TestParse/a","Elapsed":0}
+{"Action":"run","Package":"example/pkg","Test":"TestParse/b"}
+{"Action":"pass","Package":"example/pkg","Test":"TestParse/b","Elapsed":0}
+{"Action":"fail","Package":"example/pkg","Test":"TestParse","Elapsed":0}
+{"Action":"run","Package":"other/pkg","Test":"TestParse/a"}
+{"Action":"pass","Package":"other/pkg","Test":"TestParse/a","Elapsed":0}`
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	root := result.TestDetails["example/pkg.TestParse"]
+	if root == nil || len(root.Children) != 2 {
+		t.Fatalf("Expected TestParse with 2 children, got %+v", root)
+	}
+	if root.Subtests.Failed != 1 || root.Subtests.Passed != 1 {
+		t.Errorf("Expected 1 failed and 1 passed subtest, got %+v", root.Subtests)
+	}
+	// 不同包中没有父测试的子测试不应挂到其他包的父测试下
+	orphan := result.TestDetails["other/pkg.TestParse/a"]
+	if orphan == nil || orphan.Parent != "" {
+		t.Errorf("Expected other/pkg.TestParse/a without parent, got %+v", orphan)
+	}
+	failed := result.FailedDescendants(root)
+	if len(failed) != 1 || !strings.Contains(failed[0].Output, "boom") {
+		t.Errorf("Expected failing leaf with output, got %d entries", len(failed))
+	}
+}
+
+// TestParseTestLog_NestedSubtestError 测试 JSON 日志中嵌套子测试的错误信息来自日志而不是 --- FAIL 框架行
+func TestParseTestLog_NestedSubtestError(t *testing.T) {
+	// Arrange
+	testInput := `{"Action":"run","Package":"example","Test":"TestSub"}
+{"Action":"output","Package":"example","Test":"TestSub","Output":"=== RUN   TestSub\n"}
+{"Action":"run","Package":"example","Test":"TestSub/bad"}
+{"Action":"output","Package":"example","Test":"TestSub/bad","Output":"=== RUN   TestSub/bad\n"}
+{"Action":"run","Package":"example","Test":"TestSub/bad/deep"}
+{"Action":"output","Package":"example","Test":"TestSub/bad/deep","Output":"=== RUN   TestSub/bad/deep\n"}
+{"Action":"output","Package":"example","Test":"TestSub/bad/deep","Output":"    deep_test.go:12: want 1, got 2\n"}
+{"Action":"output","Package":"example","Test":"TestSub/bad/deep","Output":"--- FAIL: TestSub/bad/deep (0.00s)\n"}
+{"Action":"fail","Package":"example","Test":"TestSub/bad/deep","Elapsed":0}
+{"Action":"output","Package":"example","Test":"TestSub/bad","Output":"--- FAIL: TestSub/bad (0.00s)\n"}
+{"Action":"fail","Package":"example","Test":"TestSub/bad","Elapsed":0}
+{"Action":"output","Package":"example","Test":"TestSub","Output":"--- FAIL: TestSub (0.00s)\n"}
+{"Action":"fail","Package":"example","Test":"TestSub","Elapsed":0}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(testInput))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detail := result.TestDetails["example.TestSub/bad/deep"]; detail.Error != "deep_test.go:12: want 1, got 2" {
+		t.Errorf("Expected error from the log entry, got %q", detail.Error)
+	}
+	for _, key := range []string{"example.TestSub/bad", "example.TestSub"} {
+		if detail := result.TestDetails[key]; strings.Contains(detail.Error, "--- FAIL") || strings.Contains(detail.Error, "=== RUN") {
+			t.Errorf("Expected %s error not to be a frame line, got %q", key, detail.Error)
+		}
+	}
+	if detail := result.TestDetails["example.TestSub"]; detail.Error != "1 subtest(s) failed:\nTestSub/bad/deep: deep_test.go:12: want 1, got 2" {
+		t.Errorf("Expected parent error to summarize the failing subtest, got %q", detail.Error)
+	}
+}
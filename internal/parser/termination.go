@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 运行被中断的原因
+const (
+	TerminationOutOfMemory = "out_of_memory" // fatal error: runtime: out of memory
+	TerminationFatalError  = "fatal_error"   // 其他 fatal error，如 concurrent map writes、deadlock
+	TerminationPanic       = "panic"         // 测试之外的 panic 或 panic 导致进程退出
+	TerminationSignal      = "signal"        // 进程被信号终止，如 signal: killed
+	TerminationExitStatus  = "exit_status"   // 只看到 exit status N
+	TerminationTruncated   = "truncated"     // 日志在测试运行中途结束，没有任何终止信息
+)
+
+var (
+	signalPattern     = regexp.MustCompile(`^signal: (.+)$`)
+	exitStatusPattern = regexp.MustCompile(`^exit status (\d+)$`)
+	// go test 在 -timeout 之后再等待一段时间仍未结束时强制终止测试二进制
+	testKilledPattern = regexp.MustCompile(`^\*\*\* Test killed(?: with (\S+))?:`)
+)
+
+// TerminationInfo 运行被中断时的进程终止信息
+type TerminationInfo struct {
+	Package      string   `json:"package"`
+	Cause        string   `json:"cause"`
+	Signal       string   `json:"signal,omitempty"`
+	ExitStatus   int      `json:"exit_status,omitempty"`
+	Message      string   `json:"message,omitempty"` // 第一条 fatal error / panic 行
+	RunningTests []string `json:"running_tests"`     // 中断时仍在运行的测试，TestDetails 中的键
+}
+
+// terminationState 收集一个包内与进程终止相关的输出行
+type terminationState struct {
+	message    string
+	signal     string
+	exitStatus int
+}
+
+// observe 检查一行输出是否包含终止信息，fatal error / panic 只保留第一条（根因），信号和退出状态保留最后一条
+func (s *terminationState) observe(trimmed string) {
+	switch {
+	case strings.HasPrefix(trimmed, "fatal error: ") || strings.HasPrefix(trimmed, "panic: "):
+		if s.message == "" {
+			s.message = trimmed
+		}
+	case signalPattern.MatchString(trimmed):
+		s.signal = signalPattern.FindStringSubmatch(trimmed)[1]
+	case exitStatusPattern.MatchString(trimmed):
+		s.exitStatus, _ = strconv.Atoi(exitStatusPattern.FindStringSubmatch(trimmed)[1])
+	case testKilledPattern.MatchString(trimmed):
+		s.signal = testKilledPattern.FindStringSubmatch(trimmed)[1]
+		if s.signal == "" {
+			s.signal = "killed"
+		}
+	}
+}
+
+// crashed 判断进程是否异常终止：出现 fatal error / panic、被信号终止或退出状态大于 1（测试失败时退出状态为 1）
+func (s *terminationState) crashed() bool {
+	return s != nil && (s.message != "" || s.signal != "" || s.exitStatus > 1)
+}
+
+// info 根据收集到的输出生成终止信息，原因按 fatal error、panic、信号、退出状态的顺序取最具体的一个；
+// 没有收集到任何输出（s 为 nil）时原因为 truncated
+func (s *terminationState) info(pkg string) *TerminationInfo {
+	if s == nil {
+		s = &terminationState{}
+	}
+	info := &TerminationInfo{
+		Package:      pkg,
+		Signal:       s.signal,
+		ExitStatus:   s.exitStatus,
+		Message:      s.message,
+		RunningTests: make([]string, 0),
+	}
+	switch {
+	case strings.HasPrefix(s.message, "fatal error: runtime: out of memory"):
+		info.Cause = TerminationOutOfMemory
+	case strings.HasPrefix(s.message, "fatal error: "):
+		info.Cause = TerminationFatalError
+	case s.message != "":
+		info.Cause = TerminationPanic
+	case s.signal != "":
+		info.Cause = TerminationSignal
+	case s.exitStatus != 0:
+		info.Cause = TerminationExitStatus
+	default:
+		info.Cause = TerminationTruncated
+	}
+	return info
+}
+
+// applyIncomplete 将包内仍处于 running 状态的测试标记为 incomplete，并记录进程终止原因；
+// 需要在 applyTimeout 之后调用，超时的测试已标记为 timeout。
+// 基准测试通过时没有结束事件，由解析器在下一个测试开始或包结束时结束，不参与判断
+func applyIncomplete(result *TestResult, pkg string, state *terminationState) {
+	keys := make([]string, 0)
+	for key, detail := range result.TestDetails {
+		if detail.Package == pkg && detail.Status == "running" && testKind(detail.Name) != KindBenchmark {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+	
+	info := state.info(pkg)
+	info.RunningTests = keys
+	result.Incomplete = true
+	result.Terminations = append(result.Terminations, *info)
+	for _, key := range keys {
+		detail := result.TestDetails[key]
+		detail.Status = "incomplete"
+		detail.Termination = info
+		result.IncompleteTests++
+		result.IncompleteTestNames = append(result.IncompleteTestNames, key)
+	}
+}
+
+// Description 返回终止原因的简短描述
+func (t TerminationInfo) Description() string {
+	switch t.Cause {
+	case TerminationOutOfMemory, TerminationFatalError, TerminationPanic:
+		return t.Message
+	case TerminationSignal:
+		return "signal: " + t.Signal
+	case TerminationExitStatus:
+		return fmt.Sprintf("exit status %d", t.ExitStatus)
+	default:
+		return "log ended while tests were still running"
+	}
+}
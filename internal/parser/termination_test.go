@@ -0,0 +1,262 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseTestTextLog_KilledRun 测试进程被信号终止时仍在运行的测试标记为 incomplete
+func TestParseTestTextLog_KilledRun(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestUpload
+=== RUN   TestParse
+--- PASS: TestParse (0.00s)
+=== CONT  TestUpload
+    upload_test.go:20: uploading 1GB
+signal: killed
+FAIL	example.com/app	30.012s`
+	
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Incomplete || result.IncompleteTests != 1 || result.TotalTests != 2 {
+		t.Errorf("Expected 1 incomplete of 2 tests, got incomplete=%v count=%d total=%d", result.Incomplete, result.IncompleteTests, result.TotalTests)
+	}
+	if len(result.IncompleteTestNames) != 1 || result.IncompleteTestNames[0] != "example.com/app.TestUpload" {
+		t.Errorf("Unexpected incomplete test names: %v", result.IncompleteTestNames)
+	}
+	if len(result.Terminations) != 1 {
+		t.Fatalf("Expected 1 termination, got %+v", result.Terminations)
+	}
+	termination := result.Terminations[0]
+	if termination.Cause != TerminationSignal || termination.Signal != "killed" || termination.Package != "example.com/app" {
+		t.Errorf("Unexpected termination: %+v", termination)
+	}
+	detail := result.TestDetails["example.com/app.TestUpload"]
+	if detail.Status != "incomplete" || detail.Termination == nil || !strings.Contains(detail.Output, "uploading 1GB") {
+		t.Errorf("Unexpected incomplete detail: %+v", detail)
+	}
+	packageResult, _ := result.LookupPackage("example.com/app")
+	if packageResult.Incomplete != 1 || packageResult.Passed != 1 {
+		t.Errorf("Unexpected package counts: %+v", packageResult)
+	}
+	if result.PackageFailures != 0 || packageResult.FailureCause != "" || packageResult.FailureReason != "signal: killed" {
+		t.Errorf("Expected the killed package to report its termination, not an unexplained failure: failures=%d %+v", result.PackageFailures, packageResult)
+	}
+}
+
+// TestParseTestLog_OutOfMemory 测试 JSON 日志中 OOM 导致的中断，fatal error 优先于 exit status
+func TestParseTestLog_OutOfMemory(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestBig"}
+{"Action":"run","Package":"example","Test":"TestBig/child"}
+{"Action":"output","Package":"example","Test":"TestBig/child","Output":"fatal error: runtime: out of memory\n"}
+{"Action":"output","Package":"example","Test":"TestBig/child","Output":"\n"}
+{"Action":"output","Package":"example","Test":"TestBig/child","Output":"goroutine 7 [running]:\n"}
+{"Action":"output","Package":"example","Output":"exit status 2\n"}
+{"Action":"output","Package":"example","Output":"FAIL\texample\t1.500s\n"}
+{"Action":"fail","Package":"example","Elapsed":1.5}`
+	
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.IncompleteTests != 2 || len(result.Terminations) != 1 {
+		t.Fatalf("Expected 2 incomplete tests in 1 termination, got %d %+v", result.IncompleteTests, result.Terminations)
+	}
+	termination := result.Terminations[0]
+	if termination.Cause != TerminationOutOfMemory || termination.ExitStatus != 2 || termination.Message != "fatal error: runtime: out of memory" {
+		t.Errorf("Unexpected termination: %+v", termination)
+	}
+	if len(termination.RunningTests) != 2 || termination.RunningTests[0] != "example.TestBig" {
+		t.Errorf("Unexpected running tests: %v", termination.RunningTests)
+	}
+	if subtests := result.TestDetails["example.TestBig"].Subtests; subtests == nil || subtests.Incomplete != 1 {
+		t.Errorf("Expected incomplete subtest to be counted, got %+v", subtests)
+	}
+	if packageResult, _ := result.LookupPackage("example"); result.PackageFailures != 0 || packageResult.FailureReason != "fatal error: runtime: out of memory" {
+		t.Errorf("Expected the package failure reason to be the termination, got failures=%d %+v", result.PackageFailures, packageResult)
+	}
+	if description := termination.Description(); description != "fatal error: runtime: out of memory" {
+		t.Errorf("Unexpected description: %q", description)
+	}
+}
+
+// TestParseTestLog_TruncatedLog 测试日志在测试中途结束且没有任何终止信息
+func TestParseTestLog_TruncatedLog(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestA"}
+{"Action":"pass","Package":"example","Test":"TestA","Elapsed":0}
+{"Action":"run","Package":"example","Test":"TestB"}
+{"Action":"output","Package":"example","Test":"TestB","Output":"=== RUN   TestB\n"}`
+	
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Incomplete || len(result.Terminations) != 1 || result.Terminations[0].Cause != TerminationTruncated {
+		t.Errorf("Expected truncated termination, got %+v", result.Terminations)
+	}
+	if result.TestDetails["example.TestA"].Status != "pass" || result.TestDetails["example.TestB"].Status != "incomplete" {
+		t.Errorf("Unexpected statuses: A=%s B=%s", result.TestDetails["example.TestA"].Status, result.TestDetails["example.TestB"].Status)
+	}
+}
+
+// TestParseTestTextLog_TimeoutIsNotIncomplete 测试超时的测试不重复计为 incomplete
+func TestParseTestTextLog_TimeoutIsNotIncomplete(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestHang
+panic: test timed out after 1s
+	running tests:
+		TestHang (1s)
+	
+goroutine 1 [running]:
+exit status 2
+FAIL	example.com/app	1.005s`
+	
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.TimedOutTests != 1 || result.Incomplete || result.IncompleteTests != 0 {
+		t.Errorf("Expected timeout only, got timed_out=%d incomplete=%v", result.TimedOutTests, result.Incomplete)
+	}
+}
+
+// TestParseTestLog_BenchmarksAreNotIncomplete 测试 go test -json -bench 的真实输出：基准测试没有 pass 事件，
+// 通过的基准测试在结果行、下一个测试开始或包结束时结束，另一个基准测试失败时也不标记为 incomplete
+func TestParseTestLog_BenchmarksAreNotIncomplete(t *testing.T) {
+	// Arrange
+	log := `{"Time":"2026-10-16T21:14:39.776700014Z","Action":"start","Package":"example.com/bb"}
+{"Time":"2026-10-16T21:14:39.778897712Z","Action":"output","Package":"example.com/bb","Output":"goos: linux\n"}
+{"Time":"2026-10-16T21:14:39.77890503Z","Action":"output","Package":"example.com/bb","Output":"goarch: amd64\n"}
+{"Time":"2026-10-16T21:14:39.778907689Z","Action":"output","Package":"example.com/bb","Output":"pkg: example.com/bb\n"}
+{"Time":"2026-10-16T21:14:39.778909775Z","Action":"output","Package":"example.com/bb","Output":"cpu: Intel(R) Xeon(R) Processor\n"}
+{"Time":"2026-10-16T21:14:39.778912684Z","Action":"run","Package":"example.com/bb","Test":"BenchmarkA"}
+{"Time":"2026-10-16T21:14:39.778914458Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkA","Output":"=== RUN   BenchmarkA\n","OutputType":"frame"}
+{"Time":"2026-10-16T21:14:39.778916846Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkA","Output":"BenchmarkA\n"}
+{"Time":"2026-10-16T21:14:39.77906399Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkA","Output":"BenchmarkA \t     100\t         1.510 ns/op\n"}
+{"Time":"2026-10-16T21:14:39.779105281Z","Action":"run","Package":"example.com/bb","Test":"BenchmarkB"}
+{"Time":"2026-10-16T21:14:39.7791074Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"=== RUN   BenchmarkB\n","OutputType":"frame"}
+{"Time":"2026-10-16T21:14:39.779113839Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"BenchmarkB\n"}
+{"Time":"2026-10-16T21:14:39.779326989Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"    bb_test.go:11: broken\n","OutputType":"error"}
+{"Time":"2026-10-16T21:14:39.7793324Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkB","Output":"--- FAIL: BenchmarkB\n","OutputType":"frame"}
+{"Time":"2026-10-16T21:14:39.779334594Z","Action":"fail","Package":"example.com/bb","Test":"BenchmarkB"}
+{"Time":"2026-10-16T21:14:39.779340112Z","Action":"run","Package":"example.com/bb","Test":"BenchmarkC"}
+{"Time":"2026-10-16T21:14:39.779342380Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkC","Output":"=== RUN   BenchmarkC\n","OutputType":"frame"}
+{"Time":"2026-10-16T21:14:39.779344521Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkC","Output":"BenchmarkC\n"}
+{"Time":"2026-10-16T21:14:39.779350903Z","Action":"run","Package":"example.com/bb","Test":"BenchmarkC/x"}
+{"Time":"2026-10-16T21:14:39.779352771Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkC/x","Output":"=== RUN   BenchmarkC/x\n","OutputType":"frame"}
+{"Time":"2026-10-16T21:14:39.779354902Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkC/x","Output":"BenchmarkC/x\n"}
+{"Time":"2026-10-16T21:14:39.779501156Z","Action":"output","Package":"example.com/bb","Test":"BenchmarkC/x","Output":"BenchmarkC/x         \t     100\t         0.3800 ns/op\n"}
+{"Time":"2026-10-16T21:14:39.779510223Z","Action":"output","Package":"example.com/bb","Output":"FAIL\n","OutputType":"frame"}
+{"Time":"2026-10-16T21:14:39.779514604Z","Action":"output","Package":"example.com/bb","Output":"exit status 1\n"}
+{"Time":"2026-10-16T21:14:39.779520039Z","Action":"output","Package":"example.com/bb","Output":"FAIL\texample.com/bb\t0.003s\n","OutputType":"frame"}
+{"Time":"2026-10-16T21:14:39.779524956Z","Action":"fail","Package":"example.com/bb","Elapsed":0.003}`
+	
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Incomplete || result.IncompleteTests != 0 || len(result.Terminations) != 0 {
+		t.Errorf("Expected no incomplete tests, got incomplete=%v count=%d terminations=%+v", result.Incomplete, result.IncompleteTests, result.Terminations)
+	}
+	for _, name := range []string{"BenchmarkA", "BenchmarkC", "BenchmarkC/x"} {
+		if detail := result.TestDetails["example.com/bb."+name]; detail.Status != "pass" {
+			t.Errorf("Expected %s to pass, got %s", name, detail.Status)
+		}
+	}
+	if detail := result.TestDetails["example.com/bb.BenchmarkB"]; detail.Status != "fail" {
+		t.Errorf("Expected BenchmarkB to fail, got %s", detail.Status)
+	}
+	if result.PassedTests != 3 || result.FailedTests != 1 || result.TotalTests != 4 {
+		t.Errorf("Unexpected counts: passed=%d failed=%d total=%d", result.PassedTests, result.FailedTests, result.TotalTests)
+	}
+}
+
+// TestParseTestTextLog_BenchmarksAreNotIncomplete 测试 -v 文本输出中只运行子基准测试的父基准测试在包结束时通过
+func TestParseTestTextLog_BenchmarksAreNotIncomplete(t *testing.T) {
+	// Arrange
+	log := `goos: linux
+goarch: amd64
+pkg: example.com/bb
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkA
+BenchmarkA 	     100	         1.220 ns/op
+BenchmarkB
+    bb_test.go:11: broken
+--- FAIL: BenchmarkB
+BenchmarkC
+BenchmarkC/x
+BenchmarkC/x         	     100	         0.3700 ns/op
+FAIL
+exit status 1
+FAIL	example.com/bb	0.003s
+FAIL`
+	
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Incomplete || len(result.Terminations) != 0 {
+		t.Errorf("Expected no incomplete tests, got %+v", result.Terminations)
+	}
+	for _, name := range []string{"BenchmarkA", "BenchmarkC", "BenchmarkC/x"} {
+		if detail := result.TestDetails["example.com/bb."+name]; detail.Status != "pass" {
+			t.Errorf("Expected %s to pass, got %s", name, detail.Status)
+		}
+	}
+	if result.PassedTests != 3 || result.FailedTests != 1 || result.TotalTests != 4 {
+		t.Errorf("Unexpected counts: passed=%d failed=%d total=%d", result.PassedTests, result.FailedTests, result.TotalTests)
+	}
+}
+
+// TestParseTestLog_CrashedBenchmark 测试基准测试 panic 导致进程退出时，仍在运行的基准测试标记为失败而不是通过
+func TestParseTestLog_CrashedBenchmark(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example.com/bb","Test":"BenchmarkP"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"=== RUN   BenchmarkP\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"BenchmarkP\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"panic: boom\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"goroutine 9 [running]:\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"example.com/bb.BenchmarkP(0x2f5bf0064608?)\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"\t/tmp/bb/bb_test.go:11 +0x25\n"}
+{"Action":"output","Package":"example.com/bb","Test":"BenchmarkP","Output":"exit status 2\n"}
+{"Action":"output","Package":"example.com/bb","Output":"FAIL\texample.com/bb\t0.005s\n","OutputType":"frame"}
+{"Action":"fail","Package":"example.com/bb","Elapsed":0.005}`
+	
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+	
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/bb.BenchmarkP"]
+	if detail.Status != "fail" || detail.Panic == nil || detail.Panic.Value != "boom" {
+		t.Errorf("Expected crashed benchmark to fail with its panic, got %+v", detail)
+	}
+	if result.FailedTests != 1 || result.PassedTests != 0 || result.Incomplete {
+		t.Errorf("Unexpected counts: failed=%d passed=%d incomplete=%v", result.FailedTests, result.PassedTests, result.Incomplete)
+	}
+}
@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SourceLocation 源码位置
+type SourceLocation struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// Assertion testify 断言失败的结构化信息
+type Assertion struct {
+	Trace    []SourceLocation `json:"trace"`
+	Error    string           `json:"error"`
+	Expected string           `json:"expected,omitempty"`
+	Actual   string           `json:"actual,omitempty"`
+	Diff     string           `json:"diff,omitempty"`
+	Test     string           `json:"test,omitempty"`
+	Messages string           `json:"messages,omitempty"`
+}
+
+var (
+	// file.go:123，Windows 路径中的盘符冒号由非贪婪匹配跳过
+	locationPattern = regexp.MustCompile(`^(.+?):(\d+)$`)
+	// actual  : 3
+	actualPattern = regexp.MustCompile(`^actual\s*:`)
+)
+
+// parseLocation 解析 file:line 格式的位置
+func parseLocation(text string) (SourceLocation, bool) {
+	matches := locationPattern.FindStringSubmatch(text)
+	if matches == nil {
+		return SourceLocation{}, false
+	}
+	line, _ := strconv.Atoi(matches[2])
+	return SourceLocation{File: matches[1], Line: line}, true
+}
+
+// splitTestifyLine 拆分 testify 输出行，格式为 "<缩进>\t<标签>:<空格>\t<值>"；
+// 续行的标签部分为空白，返回的 label 为空字符串
+func splitTestifyLine(line string) (label, value string, ok bool) {
+	text := strings.TrimLeft(strings.TrimRight(line, "\r"), " ")
+	if !strings.HasPrefix(text, "\t") {
+		return "", "", false
+	}
+	text = text[1:]
+	
+	idx := strings.Index(text, "\t")
+	if idx < 0 {
+		return "", "", false
+	}
+	labelPart := strings.TrimSpace(text[:idx])
+	value = text[idx+1:]
+	if labelPart == "" {
+		return "", value, true
+	}
+	if !strings.HasSuffix(labelPart, ":") {
+		return "", "", false
+	}
+	return strings.TrimSuffix(labelPart, ":"), value, true
+}
+
+// parseAssertions 从测试输出中提取所有 testify 断言失败块，没有时返回 nil
+func parseAssertions(output string) []Assertion {
+	var assertions []Assertion
+	var fields map[string][]string
+	currentLabel := ""
+	
+	finish := func() {
+		if fields != nil {
+			assertions = append(assertions, buildAssertion(fields))
+		}
+		fields = nil
+		currentLabel = ""
+	}
+	
+	for _, line := range strings.Split(output, "\n") {
+		label, value, ok := splitTestifyLine(line)
+		if !ok {
+			finish()
+			continue
+		}
+		if label == "Error Trace" {
+			finish()
+			fields = make(map[string][]string)
+		}
+		if fields == nil {
+			continue
+		}
+		if label != "" {
+			currentLabel = label
+		}
+		fields[currentLabel] = append(fields[currentLabel], value)
+	}
+	finish()
+	
+	return assertions
+}
+
+// buildAssertion 根据各字段内容构建断言记录
+func buildAssertion(fields map[string][]string) Assertion {
+	assertion := Assertion{
+		Trace: make([]SourceLocation, 0),
+	}
+	
+	for _, value := range fields["Error Trace"] {
+		if location, ok := parseLocation(strings.TrimSpace(value)); ok {
+			assertion.Trace = append(assertion.Trace, location)
+		}
+	}
+	
+	// Error 字段首行为断言描述，随后可能是 expected/actual 以及 Diff
+	description := make([]string, 0)
+	expected := make([]string, 0)
+	actual := make([]string, 0)
+	diff := make([]string, 0)
+	target := &description
+	for _, value := range fields["Error"] {
+		trimmed := strings.TrimSpace(value)
+		switch {
+		case target == &diff:
+			diff = append(diff, strings.TrimRight(value, " "))
+		case strings.HasPrefix(trimmed, "expected:"):
+			expected = append(expected, strings.TrimSpace(strings.TrimPrefix(trimmed, "expected:")))
+			target = &expected
+		case actualPattern.MatchString(trimmed):
+			actual = append(actual, strings.TrimSpace(trimmed[strings.Index(trimmed, ":")+1:]))
+			target = &actual
+		case trimmed == "Diff:":
+			target = &diff
+		case trimmed == "":
+			// 空续行用于分隔 actual 与 Diff
+		default:
+			*target = append(*target, trimmed)
+		}
+	}
+	assertion.Error = strings.Join(description, "\n")
+	assertion.Expected = strings.Join(expected, "\n")
+	assertion.Actual = strings.Join(actual, "\n")
+	assertion.Diff = strings.TrimRight(strings.Join(diff, "\n"), "\n")
+	
+	if values := fields["Test"]; len(values) > 0 {
+		assertion.Test = strings.TrimSpace(values[0])
+	}
+	messages := make([]string, 0)
+	for _, value := range fields["Messages"] {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			messages = append(messages, trimmed)
+		}
+	}
+	assertion.Messages = strings.Join(messages, "\n")
+	
+	return assertion
+}
+
+// formatAssertions 将断言记录格式化为错误摘要
+func formatAssertions(assertions []Assertion) string {
+	parts := make([]string, 0, len(assertions))
+	for _, assertion := range assertions {
+		text := assertion.Error
+		if len(assertion.Trace) > 0 {
+			location := assertion.Trace[0]
+			text = fmt.Sprintf("%s:%d: %s", location.File, location.Line, text)
+		}
+		if assertion.Expected != "" || assertion.Actual != "" {
+			text += fmt.Sprintf("\nexpected: %s\nactual  : %s", assertion.Expected, assertion.Actual)
+		}
+		if assertion.Messages != "" {
+			text += "\nmessages: " + assertion.Messages
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// testifyEqualOutput 模拟 assert.Equal 失败时的输出
+const testifyEqualOutput = "=== RUN   TestUpload\n" +
+	"    upload_test.go:42: \n" +
+	"        \tError Trace:\t/src/app/upload_test.go:42\n" +
+	"        \t            \t\t\t\t/src/app/helpers_test.go:17\n" +
+	"        \tError:      \tNot equal: \n" +
+	"        \t            \texpected: \"done\"\n" +
+	"        \t            \tactual  : \"pending\"\n" +
+	"        \t            \t\n" +
+	"        \t            \tDiff:\n" +
+	"        \t            \t--- Expected\n" +
+	"        \t            \t+++ Actual\n" +
+	"        \t            \t@@ -1 +1 @@\n" +
+	"        \t            \t-done\n" +
+	"        \t            \t+pending\n" +
+	"        \tTest:       \tTestUpload\n" +
+	"        \tMessages:   \tupload should complete\n" +
+	"    upload_test.go:50: got 3 files, want 3 files\n"
+
+// TestParseAssertions_Equal 测试解析 assert.Equal 失败块
+func TestParseAssertions_Equal(t *testing.T) {
+	// Act
+	assertions := parseAssertions(testifyEqualOutput)
+
+	// Assert
+	if len(assertions) != 1 {
+		t.Fatalf("Expected 1 assertion, got %d", len(assertions))
+	}
+	assertion := assertions[0]
+	if len(assertion.Trace) != 2 || assertion.Trace[0].File != "/src/app/upload_test.go" || assertion.Trace[0].Line != 42 ||
+		assertion.Trace[1].File != "/src/app/helpers_test.go" || assertion.Trace[1].Line != 17 {
+		t.Errorf("Unexpected trace: %+v", assertion.Trace)
+	}
+	if assertion.Error != "Not equal:" {
+		t.Errorf("Expected error 'Not equal:', got %q", assertion.Error)
+	}
+	if assertion.Expected != `"done"` || assertion.Actual != `"pending"` {
+		t.Errorf("Unexpected expected/actual: %q / %q", assertion.Expected, assertion.Actual)
+	}
+	expectedDiff := "--- Expected\n+++ Actual\n@@ -1 +1 @@\n-done\n+pending"
+	if assertion.Diff != expectedDiff {
+		t.Errorf("Expected diff %q, got %q", expectedDiff, assertion.Diff)
+	}
+	if assertion.Test != "TestUpload" || assertion.Messages != "upload should complete" {
+		t.Errorf("Unexpected test/messages: %q / %q", assertion.Test, assertion.Messages)
+	}
+}
+
+// TestParseAssertions_MultipleBlocks 测试多个断言块及多行错误描述
+func TestParseAssertions_MultipleBlocks(t *testing.T) {
+	// Arrange
+	output := "    a_test.go:10: \n" +
+		"        \tError Trace:\tC:/src/a_test.go:10\n" +
+		"        \tError:      \tReceived unexpected error:\n" +
+		"        \t            \tconnection refused\n" +
+		"        \tTest:       \tTestA\n" +
+		"    a_test.go:11: \n" +
+		"        \tError Trace:\tC:/src/a_test.go:11\n" +
+		"        \tError:      \tShould be true\n" +
+		"        \tTest:       \tTestA\n"
+
+	// Act
+	assertions := parseAssertions(output)
+
+	// Assert
+	if len(assertions) != 2 {
+		t.Fatalf("Expected 2 assertions, got %d", len(assertions))
+	}
+	if assertions[0].Error != "Received unexpected error:\nconnection refused" {
+		t.Errorf("Unexpected first error: %q", assertions[0].Error)
+	}
+	if assertions[0].Trace[0].File != "C:/src/a_test.go" || assertions[0].Trace[0].Line != 10 {
+		t.Errorf("Unexpected Windows trace: %+v", assertions[0].Trace)
+	}
+	if assertions[1].Error != "Should be true" || assertions[1].Expected != "" {
+		t.Errorf("Unexpected second assertion: %+v", assertions[1])
+	}
+}
+
+// TestParseAssertions_NoTestify 测试没有 testify 输出时返回 nil
+func TestParseAssertions_NoTestify(t *testing.T) {
+	if assertions := parseAssertions("    a_test.go:10: got 1, want 2\n"); assertions != nil {
+		t.Errorf("Expected nil, got %+v", assertions)
+	}
+}
+
+// TestParseTestTextLog_TestifyError 测试失败测试的错误信息来自断言块而非关键字匹配
+func TestParseTestTextLog_TestifyError(t *testing.T) {
+	// Arrange
+	testInput := testifyEqualOutput + "--- FAIL: TestUpload (0.00s)\nFAIL\nFAIL\texample.com/app\t0.010s\n"
+	reader := strings.NewReader(testInput)
+
+	// Act
+	result, err := ParseTestTextLog(reader)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.com/app.TestUpload"]
+	if detail == nil || len(detail.Assertions) != 1 {
+		t.Fatalf("Expected 1 assertion on TestUpload, got %+v", detail)
+	}
+	if !strings.HasPrefix(detail.Error, "/src/app/upload_test.go:42: Not equal:") {
+		t.Errorf("Expected error to start with trace location, got %q", detail.Error)
+	}
+	if strings.Contains(detail.Error, "got 3 files") {
+		t.Errorf("Expected unrelated got/want line to be excluded, got %q", detail.Error)
+	}
+}
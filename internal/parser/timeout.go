@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RunningTest 超时时仍在运行的测试
+type RunningTest struct {
+	Name    string `json:"name"`
+	Elapsed string `json:"elapsed"`
+}
+
+// TimeoutInfo 包测试超时信息
+type TimeoutInfo struct {
+	Package      string        `json:"package"`
+	Timeout      string        `json:"timeout"`
+	RunningTests []RunningTest `json:"running_tests"`
+	Dump         *PanicInfo    `json:"dump,omitempty"`
+}
+
+var (
+	// panic: test timed out after 10m0s
+	timeoutPattern = regexp.MustCompile(`^panic: test timed out after (\S+)`)
+	// running tests: 列表中的一项，如 "TestSlow (10m0s)"
+	runningTestPattern = regexp.MustCompile(`^(\S+) \(([^)]+)\)$`)
+)
+
+// isTimeoutStart 判断一行是否为测试超时 panic 的开始
+func isTimeoutStart(trimmed string) bool {
+	return timeoutPattern.MatchString(trimmed)
+}
+
+// parseTimeout 解析超时 panic 的输出，包括 running tests 列表和协程转储
+func parseTimeout(pkg string, lines []string) *TimeoutInfo {
+	info := &TimeoutInfo{
+		Package:      pkg,
+		RunningTests: make([]RunningTest, 0),
+	}
+	
+	inRunningTests := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if matches := timeoutPattern.FindStringSubmatch(trimmed); matches != nil {
+			info.Timeout = matches[1]
+			continue
+		}
+		if trimmed == "running tests:" {
+			inRunningTests = true
+			continue
+		}
+		if !inRunningTests {
+			continue
+		}
+		matches := runningTestPattern.FindStringSubmatch(trimmed)
+		if matches == nil {
+			inRunningTests = false
+			continue
+		}
+		info.RunningTests = append(info.RunningTests, RunningTest{Name: matches[1], Elapsed: matches[2]})
+	}
+	
+	info.Dump = parsePanic(strings.Join(lines, "\n"))
+	return info
+}
+
+// applyTimeout 将包的超时信息写入结果：running tests 列表中的测试（旧版 go 没有该列表时为包内所有
+// 仍在运行的测试）标记为 timeout，并附上超时时间和协程转储
+func applyTimeout(result *TestResult, pkg string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	info := parseTimeout(pkg, lines)
+	result.TimedOut = true
+	result.Timeouts = append(result.Timeouts, *info)
+	
+	keys := make([]string, 0)
+	if len(info.RunningTests) > 0 {
+		for _, test := range info.RunningTests {
+			key := TestKey(pkg, test.Name)
+			if _, exists := result.TestDetails[key]; !exists {
+				// 非 -v 模式下没有 === RUN 行
+				result.TestDetails[key] = &TestDetail{Package: pkg, Name: test.Name, Kind: testKind(test.Name), Status: "running"}
+			}
+			keys = append(keys, key)
+		}
+	} else {
+		for key, detail := range result.TestDetails {
+			if detail.Package == pkg && detail.Status == "running" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+	}
+	
+	for _, key := range keys {
+		detail := result.TestDetails[key]
+		detail.Timeout = info.Timeout
+		detail.Panic = info.Dump
+		if detail.Status == "running" {
+			detail.Status = "timeout"
+			result.TimedOutTests++
+			result.TimedOutTestNames = append(result.TimedOutTestNames, key)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const timeoutTextLog = `=== RUN   TestFast
+--- PASS: TestFast (0.00s)
+=== RUN   TestSlow
+=== RUN   TestSlow/wait
+panic: test timed out after 2s
+	running tests:
+		TestSlow (2s)
+		TestSlow/wait (2s)
+
+goroutine 21 [running]:
+testing.(*M).startAlarm.func1()
+	/usr/local/go/src/testing/testing.go:2259 +0x3b9
+created by time.goFunc
+	/usr/local/go/src/time/sleep.go:176 +0x2d
+
+goroutine 8 [chan receive, 1 minutes]:
+example.com/app/worker.TestSlow.func1(0xc000007a00)
+	/home/dev/app/worker/worker_test.go:31 +0x45
+testing.tRunner(0xc000007a00, 0x5ce1d8)
+	/usr/local/go/src/testing/testing.go:1595 +0xff
+created by testing.(*T).Run in goroutine 7
+	/usr/local/go/src/testing/testing.go:1648 +0x3ad
+exit status 2
+FAIL	example.com/app/worker	2.012s`
+
+// TestParseTestTextLog_Timeout 测试文本日志中超时测试的识别
+func TestParseTestTextLog_Timeout(t *testing.T) {
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(timeoutTextLog))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.TimedOut {
+		t.Fatal("Expected run to be marked as timed out")
+	}
+	if result.TimedOutTests != 2 || result.TotalTests != 3 || result.FailedTests != 0 {
+		t.Errorf("Expected 2 timed out of 3 tests, got timed_out=%d total=%d failed=%d", result.TimedOutTests, result.TotalTests, result.FailedTests)
+	}
+	if len(result.Timeouts) != 1 {
+		t.Fatalf("Expected 1 timeout, got %d", len(result.Timeouts))
+	}
+	info := result.Timeouts[0]
+	if info.Package != "example.com/app/worker" || info.Timeout != "2s" || len(info.RunningTests) != 2 {
+		t.Errorf("Unexpected timeout info: %+v", info)
+	}
+	if info.Dump == nil || len(info.Dump.Goroutines) != 2 {
+		t.Fatalf("Expected goroutine dump with 2 goroutines, got %+v", info.Dump)
+	}
+
+	detail := result.TestDetails["example.com/app/worker.TestSlow/wait"]
+	if detail == nil || detail.Status != "timeout" || detail.Timeout != "2s" {
+		t.Fatalf("Expected TestSlow/wait to time out, got %+v", detail)
+	}
+	if detail.Panic == nil || detail.Panic.FirstUserFrame == nil || detail.Panic.FirstUserFrame.Line != 31 {
+		t.Errorf("Expected first user frame at worker_test.go:31, got %+v", detail.Panic)
+	}
+	parent := result.TestDetails["example.com/app/worker.TestSlow"]
+	if parent.Subtests == nil || parent.Subtests.TimedOut != 1 {
+		t.Errorf("Expected parent to count 1 timed out subtest, got %+v", parent.Subtests)
+	}
+	if result.TestDetails["example.com/app/worker.TestFast"].Status != "pass" {
+		t.Error("Expected TestFast to keep pass status")
+	}
+}
+
+// TestParseTestLog_Timeout 测试 JSON 日志中超时测试的识别，超时转储可能出现在包级输出中
+func TestParseTestLog_Timeout(t *testing.T) {
+	// Arrange
+	log := `{"Action":"run","Package":"example","Test":"TestHang"}
+{"Action":"output","Package":"example","Test":"TestHang","Output":"=== RUN   TestHang\n"}
+{"Action":"output","Package":"example","Output":"panic: test timed out after 10m0s\n"}
+{"Action":"output","Package":"example","Output":"\trunning tests:\n"}
+{"Action":"output","Package":"example","Output":"\t\tTestHang (10m0s)\n"}
+{"Action":"output","Package":"example","Output":"\n"}
+{"Action":"output","Package":"example","Output":"goroutine 5 [select]:\n"}
+{"Action":"output","Package":"example","Output":"example.TestHang(0x0?)\n"}
+{"Action":"output","Package":"example","Output":"\t/src/example/hang_test.go:14 +0x25\n"}
+{"Action":"output","Package":"example","Output":"FAIL\texample\t600.010s\n"}
+{"Action":"fail","Package":"example","Elapsed":600.01}`
+
+	// Act
+	result, err := ParseTestLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.TimedOut || result.TimedOutTests != 1 || result.TotalTests != 1 {
+		t.Fatalf("Expected 1 timed out test, got timed_out=%v count=%d total=%d", result.TimedOut, result.TimedOutTests, result.TotalTests)
+	}
+	if len(result.TimedOutTestNames) != 1 || result.TimedOutTestNames[0] != "example.TestHang" {
+		t.Errorf("Unexpected timed out test names: %v", result.TimedOutTestNames)
+	}
+	detail := result.TestDetails["example.TestHang"]
+	if detail.Status != "timeout" || detail.Timeout != "10m0s" {
+		t.Errorf("Expected timeout status with 10m0s, got %s %s", detail.Status, detail.Timeout)
+	}
+	if detail.Panic == nil || len(detail.Panic.Goroutines) != 1 || detail.Panic.Goroutines[0].State != "select" {
+		t.Errorf("Expected goroutine dump attached, got %+v", detail.Panic)
+	}
+}
+
+// TestParseTimeout_WithoutRunningTests 测试旧版 go 没有 running tests 列表时的超时解析
+func TestParseTimeout_WithoutRunningTests(t *testing.T) {
+	// Arrange
+	log := `=== RUN   TestSlow
+panic: test timed out after 30s
+
+goroutine 1 [chan receive]:
+FAIL	example	30.005s`
+
+	// Act
+	result, err := ParseTestTextLog(strings.NewReader(log))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	detail := result.TestDetails["example.TestSlow"]
+	if detail == nil || detail.Status != "timeout" || detail.Timeout != "30s" {
+		t.Fatalf("Expected running test to be marked as timed out, got %+v", detail)
+	}
+	if len(result.Timeouts) != 1 || len(result.Timeouts[0].RunningTests) != 0 {
+		t.Errorf("Expected timeout without running tests list, got %+v", result.Timeouts)
+	}
+}
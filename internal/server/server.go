@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/allanpk716/go_test_reader/internal/coverprofile"
 	"github.com/allanpk716/go_test_reader/internal/parser"
 )
 
@@ -16,30 +18,154 @@ type MCPServer struct {
 
 // AnalyzeTestLogRequest 分析测试日志请求参数
 type AnalyzeTestLogRequest struct {
-	FilePath string `json:"file_path"`
+	FilePath     string   `json:"file_path"`
+	CoverProfile string   `json:"coverprofile,omitempty"`
+	Attributes   []string `json:"attributes,omitempty"`    // key=value 或 key，只统计满足全部条件的测试
+	GroupBy      string   `json:"group_by,omitempty"`      // 按该属性的值分组统计
+	GroupSkipped bool     `json:"group_skipped,omitempty"` // 按跳过原因分组列出跳过的测试
 }
 
 // TestOverviewResponse 测试总览响应
 type TestOverviewResponse struct {
-	AllTestsPassed   bool     `json:"all_tests_passed"`
-	TotalTests       int      `json:"total_tests"`
-	FailedTestsCount int      `json:"failed_tests_count"`
-	FailedTestNames  []string `json:"failed_test_names"`
+	AllTestsPassed    bool                     `json:"all_tests_passed"`
+	TotalTests        int                      `json:"total_tests"`
+	FailedTestsCount  int                      `json:"failed_tests_count"`
+	FailedTestNames   []string                 `json:"failed_test_names"`
+	TimedOut          bool                     `json:"timed_out"`
+	TimedOutTestNames []string                 `json:"timed_out_test_names"`
+	Timeouts          []parser.TimeoutInfo     `json:"timeouts,omitempty"`
+	TotalBenchmarks   int                      `json:"total_benchmarks"`
+	Benchmarks        []parser.Benchmark       `json:"benchmarks"`
+	Coverage          []parser.PackageCoverage `json:"coverage"`
+	OverallCoverage   *float64                 `json:"overall_coverage,omitempty"`
+	Packages          []parser.PackageResult   `json:"packages"`
+	FailedPackages    []string                 `json:"failed_packages"`
+	PackageFailures   []parser.PackageResult   `json:"package_failures"` // 没有失败测试但整体失败的包
+	AttributeGroups   []parser.AttributeGroup  `json:"attribute_groups,omitempty"`
+	SkippedTestsCount int                      `json:"skipped_tests_count"`
+	SkipReasons       []parser.SkipGroup       `json:"skip_reasons,omitempty"`
+
+	// 运行被中断（进程被杀死、OOM、日志截断）时仍在运行的测试及终止原因
+	Incomplete          bool                     `json:"incomplete"`
+	IncompleteTestNames []string                 `json:"incomplete_test_names"`
+	Terminations        []parser.TerminationInfo `json:"terminations,omitempty"`
 }
 
 // GetTestDetailsRequest 获取测试详情请求参数
 type GetTestDetailsRequest struct {
-	FilePath string `json:"file_path"`
-	TestName string `json:"test_name"`
+	FilePath   string   `json:"file_path"`
+	TestName   string   `json:"test_name"`
+	Package    string   `json:"package,omitempty"`
+	ModuleRoot string   `json:"module_root,omitempty"`
+	Attributes []string `json:"attributes,omitempty"` // 未指定 test_name 时列出满足条件的测试
+	GroupBy    string   `json:"group_by,omitempty"`
 }
 
 // TestDetailsResponse 测试详情响应
 type TestDetailsResponse struct {
-	TestName string `json:"test_name"`
-	Status   string `json:"status"`
-	Output   string `json:"output"`
-	Error    string `json:"error"`
+	TestName       string                    `json:"test_name"`
+	Package        string                    `json:"package"`
+	Kind           string                    `json:"kind"`
+	Status         string                    `json:"status"`
+	Output         string                    `json:"output"`
+	Error          string                    `json:"error"`
+	Elapsed        float64                   `json:"elapsed"`
+	Parent         string                    `json:"parent,omitempty"`
+	Children       []string                  `json:"children,omitempty"`
+	Subtests       *parser.SubtestCounts     `json:"subtests,omitempty"`
+	FailedSubtests []SubtestFailure          `json:"failed_subtests,omitempty"`
+	Panic          *parser.PanicInfo         `json:"panic,omitempty"`
+	Assertions     []parser.Assertion        `json:"assertions,omitempty"`
+	Diffs          []parser.DiffBlock        `json:"diffs,omitempty"`
+	Logs           []parser.LogEntry         `json:"logs,omitempty"`
+	Timeout        string                    `json:"timeout,omitempty"`
+	Fuzz           *parser.FuzzInfo          `json:"fuzz,omitempty"`
+	Example        *parser.ExampleOutput     `json:"example,omitempty"`
+	Attributes     map[string]string         `json:"attributes,omitempty"`
+	Skip           *parser.SkipInfo          `json:"skip,omitempty"`
+	Termination    *parser.TerminationInfo   `json:"termination,omitempty"`
+	Goroutines     *parser.GoroutineAnalysis `json:"goroutines,omitempty"` // 超时或死锁时的协程转储分析
+
+	// 按属性查询时返回匹配的测试及分组
+	MatchingTests   []TestSummary           `json:"matching_tests,omitempty"`
+	AttributeGroups []parser.AttributeGroup `json:"attribute_groups,omitempty"`
+}
+
+// TestSummary 按属性查询时返回的测试摘要
+type TestSummary struct {
+	TestName   string            `json:"test_name"`
+	Package    string            `json:"package"`
+	Status     string            `json:"status"`
+	Error      string            `json:"error,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// ListBuildDiagnosticsRequest 列出编译诊断请求参数
+type ListBuildDiagnosticsRequest struct {
+	FilePath string `json:"file_path"`
+	Package  string `json:"package,omitempty"`
+}
+
+// BuildDiagnosticsResponse 编译诊断响应
+type BuildDiagnosticsResponse struct {
+	TotalDiagnostics int                         `json:"total_diagnostics"`
+	Packages         []parser.PackageDiagnostics `json:"packages"`
+}
+
+// ListDataRacesRequest 列出数据竞争请求参数
+type ListDataRacesRequest struct {
+	FilePath string `json:"file_path"`
+	Package  string `json:"package,omitempty"`
+	TestName string `json:"test_name,omitempty"`
+}
+
+// DataRacesResponse 数据竞争响应
+type DataRacesResponse struct {
+	TotalRaces int               `json:"total_races"`
+	Races      []parser.DataRace `json:"races"`
+}
+
+// CompareBenchmarksRequest 比较基准测试请求参数
+type CompareBenchmarksRequest struct {
+	BaseFilePath string  `json:"base_file_path"`
+	HeadFilePath string  `json:"head_file_path"`
+	Alpha        float64 `json:"alpha,omitempty"`
+}
+
+// CompareBenchmarksResponse 比较基准测试响应
+type CompareBenchmarksResponse struct {
+	Alpha        float64                 `json:"alpha"`
+	Regressions  int                     `json:"regressions"`
+	Improvements int                     `json:"improvements"`
+	Deltas       []parser.BenchmarkDelta `json:"deltas"`
+}
+
+// GetCoverageRequest 查询覆盖率请求参数
+type GetCoverageRequest struct {
+	CoverProfiles []string `json:"coverprofiles"`
+	ModuleRoot    string   `json:"module_root,omitempty"`
+	File          string   `json:"file,omitempty"`
+	Function      string   `json:"function,omitempty"`
+}
+
+// CoverageResponse 覆盖率响应
+type CoverageResponse struct {
+	Mode         string                      `json:"mode"`
+	Covered      int                         `json:"covered"`
+	Total        int                         `json:"total"`
+	Percent      float64                     `json:"percent"`
+	Files        []coverprofile.FileCoverage `json:"files"`
+	Functions    []coverprofile.FuncCoverage `json:"functions"`
+	SourceErrors []coverprofile.SourceError  `json:"source_errors"` // 源码缺失或无法解析、没有函数覆盖率的文件
+}
+
+// SubtestFailure 失败的子测试
+type SubtestFailure struct {
+	TestName string  `json:"test_name"`
+	Output   string  `json:"output"`
+	Error    string  `json:"error"`
 	Elapsed  float64 `json:"elapsed"`
+	IsLeaf   bool    `json:"is_leaf"`
 }
 
 // NewMCPServer 创建新的 MCP 服务器
@@ -68,19 +194,47 @@ func (s *MCPServer) registerTools() {
 	// 注册测试日志分析工具
 	analyzeTool := mcp.NewServerTool(
 		"analyze_test_log",
-		"分析 go test 输出的测试日志文件，返回测试总览信息",
+		"分析 go test 输出的测试日志文件，返回测试总览信息及各包覆盖率，可选 coverprofile 参数用于计算按语句数加权的总覆盖率；可选 attributes（如 owner=payments）只统计带有这些 t.Attr 属性的测试，group_by 按属性值分组统计；group_skipped 为 true 时按 t.Skip 原因分组列出跳过的测试",
 		s.handleAnalyzeTestLog,
 	)
 	
 	// 注册测试详情查询工具
 	detailsTool := mcp.NewServerTool(
 		"get_test_details",
-		"根据文件路径和测试名称获取详细错误信息",
+		"根据文件路径和测试名称获取详细错误信息，同时返回失败的子测试；可选 package 参数用于区分不同包中的同名测试，可选 module_root 参数用于加载模糊测试的失败语料；不指定 test_name 时可用 attributes、group_by 按 t.Attr 属性列出并分组测试；超时或死锁的测试会附带按栈和等待原因分组的协程转储分析",
 		s.handleGetTestDetails,
 	)
 	
+	// 注册编译诊断查询工具
+	diagnosticsTool := mcp.NewServerTool(
+		"list_build_diagnostics",
+		"列出测试日志中的编译错误，按包分组返回文件、行、列、错误信息及 have/want 说明，可选 package 参数只返回指定包",
+		s.handleListBuildDiagnostics,
+	)
+	
+	// 注册数据竞争查询工具
+	racesTool := mcp.NewServerTool(
+		"list_data_races",
+		"列出 -race 运行中检测到的数据竞争，返回读写访问栈、之前的访问、协程创建位置及所属测试，可选 package、test_name 参数过滤",
+		s.handleListDataRaces,
+	)
+	
+	// 注册基准测试比较工具
+	compareTool := mcp.NewServerTool(
+		"compare_benchmarks",
+		"比较 base 和 head 两份测试日志中的基准测试（类似 benchstat），按指标返回中位数变化百分比、Mann-Whitney U 检验 p 值及是否退化，可选 alpha 参数设置显著性水平（默认 0.05）",
+		s.handleCompareBenchmarks,
+	)
+	
+	// 注册覆盖率查询工具
+	coverageTool := mcp.NewServerTool(
+		"get_coverage",
+		"读取一个或多个 -coverprofile 覆盖率文件（合并后统计），返回总覆盖率、按文件的覆盖率及未覆盖行范围；提供 module_root 时解析源码返回按函数的覆盖率，源码缺失或无法解析的文件列在 source_errors 中，可选 file、function 参数过滤",
+		s.handleGetCoverage,
+	)
+	
 	// 添加工具到服务器
-	s.server.AddTools(analyzeTool, detailsTool)
+	s.server.AddTools(analyzeTool, detailsTool, diagnosticsTool, racesTool, compareTool, coverageTool)
 }
 
 // handleAnalyzeTestLog 处理测试日志分析
@@ -90,45 +244,175 @@ func (s *MCPServer) handleAnalyzeTestLog(ctx context.Context, session *mcp.Serve
 		return nil, fmt.Errorf("file_path parameter is required")
 	}
 	
-	// 打开文件
-	file, err := os.Open(filePath)
+	// 打开并解析测试日志
+	result, err := s.parseTestLogFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 	
-	// 解析测试日志
-	result, err := s.parseTestLogWithAutoDetection(file)
+	filters, err := parser.ParseAttributeFilters(params.Arguments.Attributes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse test log: %w", err)
+		return nil, err
 	}
 	
 	// 构建响应
-	allTestsPassed := result.FailedTests == 0
+	// 超时、被中断的运行或失败的包即使没有失败的测试也不能算通过
+	failedPackages := result.FailedPackages()
+	allTestsPassed := result.FailedTests == 0 && !result.TimedOut && !result.Incomplete && len(failedPackages) == 0
 	response := TestOverviewResponse{
-		AllTestsPassed:   allTestsPassed,
-		TotalTests:       result.TotalTests,
-		FailedTestsCount: result.FailedTests,
-		FailedTestNames:  result.FailedTestNames,
+		AllTestsPassed:      allTestsPassed,
+		TotalTests:          result.TotalTests,
+		FailedTestsCount:    result.FailedTests,
+		FailedTestNames:     result.FailedTestNames,
+		TimedOut:            result.TimedOut,
+		TimedOutTestNames:   result.TimedOutTestNames,
+		Timeouts:            result.Timeouts,
+		TotalBenchmarks:     len(result.Benchmarks),
+		Benchmarks:          result.Benchmarks,
+		Coverage:            result.Coverage,
+		SkippedTestsCount:   result.SkippedTests,
+		Packages:            result.PackageResults,
+		FailedPackages:      make([]string, 0),
+		PackageFailures:     make([]parser.PackageResult, 0),
+		Incomplete:          result.Incomplete,
+		IncompleteTestNames: result.IncompleteTestNames,
+		Terminations:        result.Terminations,
+	}
+	for _, packageResult := range failedPackages {
+		response.FailedPackages = append(response.FailedPackages, packageResult.Package)
+		if packageResult.FailureCause != "" {
+			response.PackageFailures = append(response.PackageFailures, packageResult)
+		}
+	}
+	
+	// 按属性过滤时只统计匹配的测试，结论也只针对这些测试
+	matched := result.TestsWithAttributes(filters)
+	if len(filters) > 0 {
+		response.FailedTestNames = filterTestNames(result, result.FailedTestNames, filters)
+		response.TimedOutTestNames = filterTestNames(result, result.TimedOutTestNames, filters)
+		response.IncompleteTestNames = filterTestNames(result, result.IncompleteTestNames, filters)
+		response.FailedTestsCount = len(response.FailedTestNames)
+		response.TotalTests = 0
+		for _, detail := range matched {
+			if detail.Status != "running" {
+				response.TotalTests++
+			}
+		}
+		// 运行被中断时未出现在日志中的测试也可能匹配条件，因此不能算通过；
+		// 同理，编译失败或 TestMain、init 导致的包失败（没有失败的测试）影响包内所有测试
+		response.AllTestsPassed = response.FailedTestsCount == 0 && len(response.TimedOutTestNames) == 0 && !result.Incomplete
+		for _, packageResult := range failedPackages {
+			if packageResult.Failed == 0 && packageResult.TimedOut == 0 {
+				response.AllTestsPassed = false
+			}
+		}
+	}
+	if params.Arguments.GroupBy != "" {
+		response.AttributeGroups = parser.GroupByAttribute(matched, params.Arguments.GroupBy)
+	}
+	if params.Arguments.GroupSkipped {
+		response.SkipReasons = result.SkipReasons()
+	}
+	
+	// 各包的百分比无法直接平均，总覆盖率需要覆盖率文件中的语句数加权
+	if params.Arguments.CoverProfile != "" {
+		profile, err := coverprofile.ParseFile(params.Arguments.CoverProfile)
+		if err != nil {
+			return nil, err
+		}
+		overall := profile.Percent()
+		response.OverallCoverage = &overall
+	}
+	
+	text := fmt.Sprintf("测试分析完成：总计 %d 个测试，%d 个失败", response.TotalTests, response.FailedTestsCount)
+	if len(filters) > 0 {
+		text += fmt.Sprintf("（仅统计带有属性 %s 的测试）", strings.Join(params.Arguments.Attributes, ", "))
+	}
+	if params.Arguments.GroupBy != "" {
+		text += fmt.Sprintf("，按属性 %s 分为 %d 组", params.Arguments.GroupBy, len(response.AttributeGroups))
+	}
+	if params.Arguments.GroupSkipped {
+		text += fmt.Sprintf("，%d 个测试被跳过（%d 种原因）", response.SkippedTestsCount, len(response.SkipReasons))
+	}
+	if len(response.Packages) > 0 {
+		text += fmt.Sprintf("，%d 个包中 %d 个失败", len(response.Packages), len(response.FailedPackages))
+	}
+	for _, packageResult := range response.PackageFailures {
+		text += fmt.Sprintf("，包 %s 没有失败的测试但整体失败（%s）", packageResult.Package, strings.SplitN(packageResult.FailureReason, "\n", 2)[0])
+	}
+	if result.TimedOut {
+		text += fmt.Sprintf("，运行超时，%d 个测试超时", result.TimedOutTests)
+	}
+	for _, termination := range response.Terminations {
+		text += fmt.Sprintf("，运行被中断（%s），%d 个测试未完成", termination.Description(), len(termination.RunningTests))
+	}
+	if response.OverallCoverage != nil {
+		text += fmt.Sprintf("，总覆盖率 %.1f%%", *response.OverallCoverage)
+	}
+	if response.TotalBenchmarks > 0 {
+		text += fmt.Sprintf("，%d 条基准测试结果", response.TotalBenchmarks)
 	}
 	
 	return &mcp.CallToolResultFor[TestOverviewResponse]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
-				Text: fmt.Sprintf("测试分析完成：总计 %d 个测试，%d 个失败", result.TotalTests, result.FailedTests),
+				Text: text,
 			},
 		},
 		Meta: mcp.Meta{
-			"all_tests_passed":   response.AllTestsPassed,
-			"total_tests":        response.TotalTests,
-			"failed_tests_count": response.FailedTestsCount,
-			"failed_test_names":  response.FailedTestNames,
+			"all_tests_passed":      response.AllTestsPassed,
+			"total_tests":           response.TotalTests,
+			"failed_tests_count":    response.FailedTestsCount,
+			"failed_test_names":     response.FailedTestNames,
+			"timed_out":             response.TimedOut,
+			"timed_out_test_names":  response.TimedOutTestNames,
+			"timeouts":              response.Timeouts,
+			"total_benchmarks":      response.TotalBenchmarks,
+			"benchmarks":            response.Benchmarks,
+			"coverage":              response.Coverage,
+			"overall_coverage":      response.OverallCoverage,
+			"packages":              response.Packages,
+			"failed_packages":       response.FailedPackages,
+			"package_failures":      response.PackageFailures,
+			"attribute_groups":      response.AttributeGroups,
+			"skipped_tests_count":   response.SkippedTestsCount,
+			"skip_reasons":          response.SkipReasons,
+			"incomplete":            response.Incomplete,
+			"incomplete_test_names": response.IncompleteTestNames,
+			"terminations":          response.Terminations,
 		},
 	}, nil
 }
 
 
 
+// filterTestNames 保留满足属性条件的测试标识，保持原有顺序
+func filterTestNames(result *parser.TestResult, names []string, filters []parser.AttributeFilter) []string {
+	filtered := make([]string, 0)
+	for _, name := range names {
+		if detail, exists := result.TestDetails[name]; exists && detail.MatchesAttributes(filters) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
+
+// parseTestLogFile 打开文件并自动检测格式解析
+func (s *MCPServer) parseTestLogFile(filePath string) (*parser.TestResult, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+	
+	result, err := s.parseTestLogWithAutoDetection(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse test log: %w", err)
+	}
+	
+	return result, nil
+}
+
 // parseTestLogWithAutoDetection 自动检测文件格式并解析
 func (s *MCPServer) parseTestLogWithAutoDetection(file *os.File) (*parser.TestResult, error) {
 	// 首先尝试检测是否为 JSON 格式
@@ -160,51 +444,391 @@ func (s *MCPServer) handleGetTestDetails(ctx context.Context, session *mcp.Serve
 		return nil, fmt.Errorf("file_path parameter is required")
 	}
 	
+	filters, err := parser.ParseAttributeFilters(params.Arguments.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	
 	testName := params.Arguments.TestName
-	if testName == "" {
+	if testName == "" && len(filters) == 0 && params.Arguments.GroupBy == "" {
 		return nil, fmt.Errorf("test_name parameter is required")
 	}
 	
-	// 打开文件
-	file, err := os.Open(filePath)
+	// 打开并解析测试日志
+	result, err := s.parseTestLogFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 	
-	// 解析测试日志
-	result, err := s.parseTestLogWithAutoDetection(file)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse test log: %w", err)
+	if testName == "" {
+		return testsByAttributes(result, filters, params.Arguments.GroupBy), nil
 	}
 	
-	// 查找指定的测试详情
-	testDetail, exists := result.TestDetails[testName]
-	if !exists {
-		return nil, fmt.Errorf("test not found: %s", testName)
+	// 查找指定的测试详情，未指定包名且存在同名测试时返回歧义错误
+	testDetail, err := result.LookupTest(params.Arguments.Package, testName)
+	if err != nil {
+		return nil, err
+	}
+	if !testDetail.MatchesAttributes(filters) {
+		return nil, fmt.Errorf("test %s does not have attributes %s", testName, strings.Join(params.Arguments.Attributes, ", "))
 	}
 	
 	// 构建响应
 	response := TestDetailsResponse{
-		TestName: testName,
-		Status:   testDetail.Status,
-		Output:   testDetail.Output,
-		Error:    testDetail.Error,
-		Elapsed:  testDetail.Elapsed,
+		TestName:       testName,
+		Package:        testDetail.Package,
+		Kind:           testDetail.Kind,
+		Status:         testDetail.Status,
+		Output:         testDetail.Output,
+		Error:          testDetail.Error,
+		Elapsed:        testDetail.Elapsed,
+		Parent:         testDetail.Parent,
+		Children:       testDetail.Children,
+		Subtests:       testDetail.Subtests,
+		FailedSubtests: make([]SubtestFailure, 0),
+		Panic:          testDetail.Panic,
+		Assertions:     testDetail.Assertions,
+		Diffs:          testDetail.Diffs,
+		Logs:           testDetail.Logs,
+		Timeout:        testDetail.Timeout,
+		Fuzz:           testDetail.Fuzz,
+		Example:        testDetail.Example,
+		Attributes:     testDetail.Attributes,
+		Skip:           testDetail.Skip,
+		Termination:    testDetail.Termination,
+		Goroutines:     testDetail.AnalyzeGoroutines(),
+	}
+	
+	// 附带失败的子测试，叶子子测试通常包含真正的断言信息
+	for _, sub := range result.FailedDescendants(testDetail) {
+		response.FailedSubtests = append(response.FailedSubtests, SubtestFailure{
+			TestName: sub.Name,
+			Output:   sub.Output,
+			Error:    sub.Error,
+			Elapsed:  sub.Elapsed,
+			IsLeaf:   len(sub.Children) == 0,
+		})
+	}
+	
+	text := fmt.Sprintf("测试 %s 的详细信息", testName)
+	if response.Status == "timeout" {
+		text += fmt.Sprintf("，测试超时（-timeout %s）", response.Timeout)
+	} else if response.Termination != nil {
+		text += fmt.Sprintf("，测试未完成，运行被中断（%s）", response.Termination.Description())
+	} else if response.Panic != nil {
+		text += fmt.Sprintf("，%s: %s", response.Panic.Kind, response.Panic.Value)
+		if frame := response.Panic.FirstUserFrame; frame != nil {
+			text += fmt.Sprintf("，位于 %s:%d (%s)", frame.File, frame.Line, frame.Function)
+		}
+	}
+	
+	// 挂起的测试通常有大量协程，只概括最多的等待原因和阻塞在测试代码中的位置
+	if goroutines := response.Goroutines; goroutines != nil {
+		text += fmt.Sprintf("，%d 个协程按栈分为 %d 组", goroutines.Total, len(goroutines.Groups))
+		if len(goroutines.WaitReasons) > 0 {
+			reason := goroutines.WaitReasons[0]
+			text += fmt.Sprintf("，最多的等待原因为 %s（%d 个）", reason.WaitReason, reason.Count)
+		}
+		if group := goroutines.Groups[0]; group.TestFrame != nil {
+			text += fmt.Sprintf("，测试代码阻塞于 %s:%d（%s，%d 个协程", group.TestFrame.File, group.TestFrame.Line, group.WaitReason, group.Count)
+			if group.MaxWaitMinutes > 0 {
+				text += fmt.Sprintf("，已阻塞 %d 分钟", group.MaxWaitMinutes)
+			}
+			text += "）"
+		}
+	}
+	
+	if skip := response.Skip; skip != nil && skip.Reason != "" {
+		text += fmt.Sprintf("，跳过原因：%s（%s:%d）", skip.Reason, skip.File, skip.Line)
+	}
+	// 错误日志只包含确知导致失败的日志（OutputType 为 error 或 testify 断言块），不会指向普通输出
+	if errorLogs := testDetail.ErrorLogs(); response.Panic == nil && len(errorLogs) > 0 {
+		text += fmt.Sprintf("，首个错误日志位于 %s:%d", errorLogs[0].File, errorLogs[0].Line)
+	}
+	
+	// 提供模块根目录时加载模糊测试的失败语料，便于直接复现
+	if fuzz := response.Fuzz; fuzz != nil && fuzz.FailingInput != "" {
+		text += fmt.Sprintf("，失败输入 %s", fuzz.FailingInput)
+		if moduleRoot := params.Arguments.ModuleRoot; moduleRoot != "" {
+			if err := fuzz.LoadFailingInput(moduleRoot, response.Package); err != nil {
+				text += fmt.Sprintf("（无法加载：%v）", err)
+			}
+		}
+	}
+	
+	return &mcp.CallToolResultFor[TestDetailsResponse]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+		Meta: mcp.Meta{
+			"test_name":       response.TestName,
+			"package":         response.Package,
+			"kind":            response.Kind,
+			"status":          response.Status,
+			"output":          response.Output,
+			"error":           response.Error,
+			"elapsed":         response.Elapsed,
+			"parent":          response.Parent,
+			"children":        response.Children,
+			"subtests":        response.Subtests,
+			"failed_subtests": response.FailedSubtests,
+			"panic":           response.Panic,
+			"assertions":      response.Assertions,
+			"diffs":           response.Diffs,
+			"logs":            response.Logs,
+			"timeout":         response.Timeout,
+			"fuzz":            response.Fuzz,
+			"example":         response.Example,
+			"attributes":      response.Attributes,
+			"skip":            response.Skip,
+			"termination":     response.Termination,
+			"goroutines":      response.Goroutines,
+		},
+	}, nil
+}
+
+// testsByAttributes 列出满足属性条件的测试，可选按属性值分组
+func testsByAttributes(result *parser.TestResult, filters []parser.AttributeFilter, groupBy string) *mcp.CallToolResultFor[TestDetailsResponse] {
+	matched := result.TestsWithAttributes(filters)
+	response := TestDetailsResponse{
+		MatchingTests: make([]TestSummary, 0, len(matched)),
+	}
+	for _, detail := range matched {
+		response.MatchingTests = append(response.MatchingTests, TestSummary{
+			TestName:   parser.TestKey(detail.Package, detail.Name),
+			Package:    detail.Package,
+			Status:     detail.Status,
+			Error:      detail.Error,
+			Attributes: detail.Attributes,
+		})
+	}
+	if groupBy != "" {
+		response.AttributeGroups = parser.GroupByAttribute(matched, groupBy)
+	}
+	
+	text := fmt.Sprintf("匹配属性条件的测试共 %d 个", len(response.MatchingTests))
+	if groupBy != "" {
+		text += fmt.Sprintf("，按属性 %s 分为 %d 组", groupBy, len(response.AttributeGroups))
 	}
 	
 	return &mcp.CallToolResultFor[TestDetailsResponse]{
 		Content: []mcp.Content{
 			&mcp.TextContent{
-				Text: fmt.Sprintf("测试 %s 的详细信息", testName),
+				Text: text,
+			},
+		},
+		Meta: mcp.Meta{
+			"matching_tests":   response.MatchingTests,
+			"attribute_groups": response.AttributeGroups,
+		},
+	}
+}
+
+// handleListBuildDiagnostics 列出编译诊断
+func (s *MCPServer) handleListBuildDiagnostics(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListBuildDiagnosticsRequest]) (*mcp.CallToolResultFor[BuildDiagnosticsResponse], error) {
+	filePath := params.Arguments.FilePath
+	if filePath == "" {
+		return nil, fmt.Errorf("file_path parameter is required")
+	}
+	
+	// 打开并解析测试日志
+	result, err := s.parseTestLogFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	
+	// 按包分组，可选只保留指定包
+	response := BuildDiagnosticsResponse{
+		Packages: make([]parser.PackageDiagnostics, 0),
+	}
+	for _, group := range result.DiagnosticsByPackage() {
+		if params.Arguments.Package != "" && group.Package != params.Arguments.Package {
+			continue
+		}
+		response.TotalDiagnostics += len(group.Diagnostics)
+		response.Packages = append(response.Packages, group)
+	}
+	
+	return &mcp.CallToolResultFor[BuildDiagnosticsResponse]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("编译诊断：%d 个包，共 %d 条错误", len(response.Packages), response.TotalDiagnostics),
+			},
+		},
+		Meta: mcp.Meta{
+			"total_diagnostics": response.TotalDiagnostics,
+			"packages":          response.Packages,
+		},
+	}, nil
+}
+
+// handleListDataRaces 列出数据竞争
+func (s *MCPServer) handleListDataRaces(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListDataRacesRequest]) (*mcp.CallToolResultFor[DataRacesResponse], error) {
+	filePath := params.Arguments.FilePath
+	if filePath == "" {
+		return nil, fmt.Errorf("file_path parameter is required")
+	}
+	
+	// 打开并解析测试日志
+	result, err := s.parseTestLogFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	
+	// 按包名和测试名过滤
+	response := DataRacesResponse{
+		Races: make([]parser.DataRace, 0),
+	}
+	for _, race := range result.Races {
+		if params.Arguments.Package != "" && race.Package != params.Arguments.Package {
+			continue
+		}
+		if params.Arguments.TestName != "" && race.Test != params.Arguments.TestName {
+			continue
+		}
+		response.Races = append(response.Races, race)
+	}
+	response.TotalRaces = len(response.Races)
+	
+	return &mcp.CallToolResultFor[DataRacesResponse]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("数据竞争：共 %d 个", response.TotalRaces),
 			},
 		},
 		Meta: mcp.Meta{
-			"test_name": response.TestName,
-			"status":    response.Status,
-			"output":    response.Output,
-			"error":     response.Error,
-			"elapsed":   response.Elapsed,
+			"total_races": response.TotalRaces,
+			"races":       response.Races,
 		},
 	}, nil
-}
\ No newline at end of file
+}
+
+// handleCompareBenchmarks 比较两份日志中的基准测试
+func (s *MCPServer) handleCompareBenchmarks(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[CompareBenchmarksRequest]) (*mcp.CallToolResultFor[CompareBenchmarksResponse], error) {
+	if params.Arguments.BaseFilePath == "" || params.Arguments.HeadFilePath == "" {
+		return nil, fmt.Errorf("base_file_path and head_file_path parameters are required")
+	}
+	
+	// 打开并解析两份测试日志
+	base, err := s.parseTestLogFile(params.Arguments.BaseFilePath)
+	if err != nil {
+		return nil, err
+	}
+	head, err := s.parseTestLogFile(params.Arguments.HeadFilePath)
+	if err != nil {
+		return nil, err
+	}
+	
+	comparison := parser.CompareBenchmarks(base, head, params.Arguments.Alpha)
+	response := CompareBenchmarksResponse{
+		Alpha:        comparison.Alpha,
+		Regressions:  comparison.Regressions,
+		Improvements: comparison.Improvements,
+		Deltas:       comparison.Deltas,
+	}
+	
+	return &mcp.CallToolResultFor[CompareBenchmarksResponse]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("基准测试比较：%d 项指标，%d 项退化，%d 项改进", len(response.Deltas), response.Regressions, response.Improvements),
+			},
+		},
+		Meta: mcp.Meta{
+			"alpha":        response.Alpha,
+			"regressions":  response.Regressions,
+			"improvements": response.Improvements,
+			"deltas":       response.Deltas,
+		},
+	}, nil
+}
+
+// handleGetCoverage 查询覆盖率文件中的文件和函数覆盖率
+func (s *MCPServer) handleGetCoverage(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GetCoverageRequest]) (*mcp.CallToolResultFor[CoverageResponse], error) {
+	args := params.Arguments
+	if len(args.CoverProfiles) == 0 {
+		return nil, fmt.Errorf("coverprofiles parameter is required")
+	}
+	if args.Function != "" && args.ModuleRoot == "" {
+		return nil, fmt.Errorf("module_root parameter is required to resolve functions")
+	}
+	
+	// 解析并合并覆盖率文件
+	profiles := make([]*coverprofile.Profile, 0, len(args.CoverProfiles))
+	for _, path := range args.CoverProfiles {
+		profile, err := coverprofile.ParseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	profile, err := coverprofile.Merge(profiles...)
+	if err != nil {
+		return nil, err
+	}
+	
+	covered, total := profile.Statements()
+	response := CoverageResponse{
+		Mode:         profile.Mode,
+		Covered:      covered,
+		Total:        total,
+		Percent:      profile.Percent(),
+		Files:        make([]coverprofile.FileCoverage, 0),
+		Functions:    make([]coverprofile.FuncCoverage, 0),
+		SourceErrors: make([]coverprofile.SourceError, 0),
+	}
+	
+	// 只查询函数时不返回文件列表
+	if args.Function == "" {
+		for _, file := range profile.Files() {
+			if args.File != "" && !coverprofile.MatchFile(file.File, args.File) {
+				continue
+			}
+			response.Files = append(response.Files, file)
+		}
+	}
+	
+	if args.ModuleRoot != "" {
+		functions, sourceErrors, err := profile.Functions(args.ModuleRoot)
+		if err != nil {
+			return nil, err
+		}
+		for _, sourceError := range sourceErrors {
+			if args.File == "" || coverprofile.MatchFile(sourceError.File, args.File) {
+				response.SourceErrors = append(response.SourceErrors, sourceError)
+			}
+		}
+		for _, function := range functions {
+			if args.File != "" && !coverprofile.MatchFile(function.File, args.File) {
+				continue
+			}
+			if args.Function != "" && !coverprofile.MatchFunc(function.Name, args.Function) {
+				continue
+			}
+			response.Functions = append(response.Functions, function)
+		}
+	}
+	
+	text := fmt.Sprintf("总覆盖率 %.1f%%（%d/%d 条语句），匹配 %d 个文件、%d 个函数", response.Percent, response.Covered, response.Total, len(response.Files), len(response.Functions))
+	if len(response.SourceErrors) > 0 {
+		text += fmt.Sprintf("，%d 个文件的源码无法解析", len(response.SourceErrors))
+	}
+	
+	return &mcp.CallToolResultFor[CoverageResponse]{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+		Meta: mcp.Meta{
+			"mode":          response.Mode,
+			"covered":       response.Covered,
+			"total":         response.Total,
+			"percent":       response.Percent,
+			"files":         response.Files,
+			"functions":     response.Functions,
+			"source_errors": response.SourceErrors,
+		},
+	}, nil
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/allanpk716/go_test_reader/internal/coverprofile"
+	"github.com/allanpk716/go_test_reader/internal/parser"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -152,4 +155,920 @@ func validTestLogContent() string {
 {"Time":"2023-01-01T00:00:01Z","Action":"pass","Package":"example","Test":"TestExample","Elapsed":0.1}
 {"Time":"2023-01-01T00:00:02Z","Action":"run","Package":"example","Test":"TestAnother"}
 {"Time":"2023-01-01T00:00:03Z","Action":"pass","Package":"example","Test":"TestAnother","Elapsed":0.2}`
-}
\ No newline at end of file
+}
+
+// TestMCPServer_HandleGetTestDetails_PackageQualified 测试按包名区分同名测试
+func TestMCPServer_HandleGetTestDetails_PackageQualified(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `{"Time":"2023-01-01T00:00:00Z","Action":"run","Package":"example/a","Test":"TestNew"}
+{"Time":"2023-01-01T00:00:01Z","Action":"fail","Package":"example/a","Test":"TestNew","Elapsed":0.1}
+{"Time":"2023-01-01T00:00:02Z","Action":"run","Package":"example/b","Test":"TestNew"}
+{"Time":"2023-01-01T00:00:03Z","Action":"pass","Package":"example/b","Test":"TestNew","Elapsed":0.2}`)
+
+	ctx := context.Background()
+	session := &mcp.ServerSession{}
+
+	// Act
+	_, ambiguousErr := server.handleGetTestDetails(ctx, session, &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, TestName: "TestNew"},
+	})
+	result, err := server.handleGetTestDetails(ctx, session, &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, TestName: "TestNew", Package: "example/b"},
+	})
+
+	// Assert
+	if ambiguousErr == nil || !strings.Contains(ambiguousErr.Error(), "ambiguous") {
+		t.Errorf("Expected ambiguity error without package, got %v", ambiguousErr)
+	}
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["package"] != "example/b" {
+		t.Errorf("Expected package=example/b, got %v", result.Meta["package"])
+	}
+	if result.Meta["status"] != "pass" {
+		t.Errorf("Expected status=pass, got %v", result.Meta["status"])
+	}
+}
+
+// TestMCPServer_HandleGetTestDetails_FailedSubtests 测试返回失败的子测试
+func TestMCPServer_HandleGetTestDetails_FailedSubtests(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `{"Action":"run","Package":"example","Test":"TestParse"}
+{"Action":"output","Package":"example","Test":"TestParse","Output":"    parse_test.go:5: loading fixtures\n"}
+{"Action":"run","Package":"example","Test":"TestParse/empty"}
+{"Action":"output","Package":"example","Test":"TestParse/empty","Output":"    parse_test.go:10: want 1, got 2\n"}
+{"Action":"fail","Package":"example","Test":"TestParse/empty","Elapsed":0}
+{"Action":"fail","Package":"example","Test":"TestParse","Elapsed":0.1}`)
+
+	ctx := context.Background()
+	session := &mcp.ServerSession{}
+	params := &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, TestName: "TestParse"},
+	}
+
+	// Act
+	result, err := server.handleGetTestDetails(ctx, session, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	failed, ok := result.Meta["failed_subtests"].([]SubtestFailure)
+	if !ok || len(failed) != 1 {
+		t.Fatalf("Expected 1 failed subtest, got %v", result.Meta["failed_subtests"])
+	}
+	if failed[0].TestName != "TestParse/empty" || !failed[0].IsLeaf {
+		t.Errorf("Expected leaf TestParse/empty, got %+v", failed[0])
+	}
+	if !strings.Contains(failed[0].Output, "want 1, got 2") {
+		t.Errorf("Expected subtest output to contain assertion, got %q", failed[0].Output)
+	}
+	// 父测试因子测试失败而失败，自身的日志不作为错误位置
+	if text := result.Content[0].(*mcp.TextContent).Text; strings.Contains(text, "parse_test.go:5") {
+		t.Errorf("Expected parent log not to be reported as error location, got %q", text)
+	}
+}
+
+// TestMCPServer_HandleListBuildDiagnostics 测试按包列出编译诊断
+func TestMCPServer_HandleListBuildDiagnostics(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	filePath := filepath.Join("..", "..", "test_data", "fail_02.txt")
+	ctx := context.Background()
+	session := &mcp.ServerSession{}
+
+	// Act
+	all, err := server.handleListBuildDiagnostics(ctx, session, &mcp.CallToolParamsFor[ListBuildDiagnosticsRequest]{
+		Arguments: ListBuildDiagnosticsRequest{FilePath: filePath},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	filtered, err := server.handleListBuildDiagnostics(ctx, session, &mcp.CallToolParamsFor[ListBuildDiagnosticsRequest]{
+		Arguments: ListBuildDiagnosticsRequest{
+			FilePath: filePath,
+			Package:  "github.com/UritMedical/lingxi.stroe/internal/client/client",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if all.Meta["total_diagnostics"] != 8 {
+		t.Errorf("Expected total_diagnostics=8, got %v", all.Meta["total_diagnostics"])
+	}
+	packages, ok := filtered.Meta["packages"].([]parser.PackageDiagnostics)
+	if !ok || len(packages) != 1 || len(packages[0].Diagnostics) != 3 {
+		t.Fatalf("Expected 3 diagnostics for internal/client/client, got %v", filtered.Meta["packages"])
+	}
+	if packages[0].Diagnostics[2].Message != "not enough arguments in call to client.createNewUpload" {
+		t.Errorf("Unexpected diagnostic message: %s", packages[0].Diagnostics[2].Message)
+	}
+}
+
+// TestMCPServer_HandleListBuildDiagnostics_EmptyFilePath 测试空文件路径
+func TestMCPServer_HandleListBuildDiagnostics_EmptyFilePath(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Act
+	_, err = server.handleListBuildDiagnostics(context.Background(), &mcp.ServerSession{}, &mcp.CallToolParamsFor[ListBuildDiagnosticsRequest]{
+		Arguments: ListBuildDiagnosticsRequest{FilePath: ""},
+	})
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "file_path parameter is required") {
+		t.Errorf("Expected file_path error, got %v", err)
+	}
+}
+
+// TestMCPServer_HandleGetTestDetails_Panic 测试返回 panic 信息及崩溃位置
+func TestMCPServer_HandleGetTestDetails_Panic(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `{"Action":"run","Package":"example","Test":"TestCrash"}
+{"Action":"output","Package":"example","Test":"TestCrash","Output":"panic: boom [recovered]\n"}
+{"Action":"output","Package":"example","Test":"TestCrash","Output":"goroutine 6 [running]:\n"}
+{"Action":"output","Package":"example","Test":"TestCrash","Output":"example.TestCrash(0x0?)\n"}
+{"Action":"output","Package":"example","Test":"TestCrash","Output":"\t/src/example/crash_test.go:8 +0x25\n"}
+{"Action":"fail","Package":"example","Test":"TestCrash","Elapsed":0}`)
+
+	params := &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, TestName: "TestCrash"},
+	}
+
+	// Act
+	result, err := server.handleGetTestDetails(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	info, ok := result.Meta["panic"].(*parser.PanicInfo)
+	if !ok || info == nil || info.Value != "boom" {
+		t.Fatalf("Expected panic info with value boom, got %v", result.Meta["panic"])
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "/src/example/crash_test.go:8") {
+		t.Errorf("Expected crash location in text content, got %q", text)
+	}
+}
+
+// TestMCPServer_HandleGetTestDetails_Logs 测试返回带源码位置的 t.Log 日志
+func TestMCPServer_HandleGetTestDetails_Logs(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `{"Action":"run","Package":"example","Test":"TestLogin"}
+{"Action":"output","Package":"example","Test":"TestLogin","Output":"    login_test.go:10: seeding users\n"}
+{"Action":"output","Package":"example","Test":"TestLogin","Output":"    login_test.go:18: want status 200, got 401\n","OutputType":"error"}
+{"Action":"output","Package":"example","Test":"TestLogin","Output":"    login_test.go:25: cleanup done\n"}
+{"Action":"fail","Package":"example","Test":"TestLogin","Elapsed":0}`)
+
+	params := &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, TestName: "TestLogin"},
+	}
+
+	// Act
+	result, err := server.handleGetTestDetails(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	logs := result.Meta["logs"].([]parser.LogEntry)
+	if len(logs) != 3 || logs[0].IsError || !logs[1].IsError || logs[1].Line != 18 || logs[2].IsError {
+		t.Errorf("Unexpected log entries: %+v", logs)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "login_test.go:18") {
+		t.Errorf("Expected first error log location in text content, got %q", text)
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_Timeout 测试超时的运行不算通过并返回超时信息
+func TestMCPServer_HandleAnalyzeTestLog_Timeout(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `=== RUN   TestHang
+panic: test timed out after 1m0s
+	running tests:
+		TestHang (1m0s)
+
+goroutine 5 [select]:
+example.TestHang(0x0?)
+	/src/example/hang_test.go:14 +0x25
+FAIL	example	60.010s`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["all_tests_passed"] != false {
+		t.Error("Expected timed out run not to be reported as passed")
+	}
+	if result.Meta["timed_out"] != true {
+		t.Error("Expected run to be reported as timed out")
+	}
+	names, ok := result.Meta["timed_out_test_names"].([]string)
+	if !ok || len(names) != 1 || names[0] != "example.TestHang" {
+		t.Errorf("Expected example.TestHang to be timed out, got %v", result.Meta["timed_out_test_names"])
+	}
+}
+
+// TestMCPServer_HandleListDataRaces 测试列出数据竞争报告
+func TestMCPServer_HandleListDataRaces(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `{"Action":"run","Package":"example","Test":"TestRace"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"WARNING: DATA RACE\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"Read at 0x00c000012345 by goroutine 7:\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"  example.TestRace.func1()\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"      /src/example/race_test.go:11 +0x2e\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"==================\n"}
+{"Action":"fail","Package":"example","Test":"TestRace","Elapsed":0}
+{"Action":"run","Package":"example","Test":"TestClean"}
+{"Action":"pass","Package":"example","Test":"TestClean","Elapsed":0}`)
+
+	params := &mcp.CallToolParamsFor[ListDataRacesRequest]{
+		Arguments: ListDataRacesRequest{FilePath: tempFile},
+	}
+
+	// Act
+	result, err := server.handleListDataRaces(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["total_races"] != 1 {
+		t.Fatalf("Expected 1 race, got %v", result.Meta["total_races"])
+	}
+	races := result.Meta["races"].([]parser.DataRace)
+	if races[0].Test != "TestRace" || races[0].Access.Op != "read" {
+		t.Errorf("Unexpected race: %+v", races[0])
+	}
+}
+
+// TestMCPServer_HandleListDataRaces_EmptyFilePath 测试空文件路径
+func TestMCPServer_HandleListDataRaces_EmptyFilePath(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	params := &mcp.CallToolParamsFor[ListDataRacesRequest]{
+		Arguments: ListDataRacesRequest{FilePath: ""},
+	}
+
+	// Act
+	_, err = server.handleListDataRaces(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for empty file path")
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_Benchmarks 测试返回基准测试结果
+func TestMCPServer_HandleAnalyzeTestLog_Benchmarks(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `goos: linux
+goarch: amd64
+pkg: example
+BenchmarkParse-8   	   12345	     98765 ns/op
+PASS
+ok  	example	1.234s`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["total_benchmarks"] != 1 {
+		t.Errorf("Expected 1 benchmark, got %v", result.Meta["total_benchmarks"])
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "1 条基准测试结果") {
+		t.Errorf("Expected benchmark count in text content, got %q", text)
+	}
+}
+
+// TestMCPServer_HandleCompareBenchmarks 测试比较两次基准测试结果
+func TestMCPServer_HandleCompareBenchmarks(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	baseFile := createTempTestFile(t, `pkg: example
+BenchmarkParse-8   	 1000	  100 ns/op
+BenchmarkParse-8   	 1000	  101 ns/op
+BenchmarkParse-8   	 1000	   99 ns/op
+BenchmarkParse-8   	 1000	  102 ns/op
+ok  	example	1.0s`)
+	headFile := createTempTestFile(t, `pkg: example
+BenchmarkParse-8   	 1000	  150 ns/op
+BenchmarkParse-8   	 1000	  151 ns/op
+BenchmarkParse-8   	 1000	  149 ns/op
+BenchmarkParse-8   	 1000	  152 ns/op
+ok  	example	1.0s`)
+
+	params := &mcp.CallToolParamsFor[CompareBenchmarksRequest]{
+		Arguments: CompareBenchmarksRequest{BaseFilePath: baseFile, HeadFilePath: headFile},
+	}
+
+	// Act
+	result, err := server.handleCompareBenchmarks(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["regressions"] != 1 {
+		t.Errorf("Expected 1 regression, got %v", result.Meta["regressions"])
+	}
+	deltas := result.Meta["deltas"].([]parser.BenchmarkDelta)
+	if len(deltas) != 1 || deltas[0].Name != "BenchmarkParse" || deltas[0].Verdict != "regressed" {
+		t.Errorf("Unexpected deltas: %+v", deltas)
+	}
+}
+
+// TestMCPServer_HandleCompareBenchmarks_MissingPath 测试缺少文件路径
+func TestMCPServer_HandleCompareBenchmarks_MissingPath(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	params := &mcp.CallToolParamsFor[CompareBenchmarksRequest]{
+		Arguments: CompareBenchmarksRequest{BaseFilePath: "base.txt"},
+	}
+
+	// Act
+	_, err = server.handleCompareBenchmarks(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for missing head file path")
+	}
+}
+
+// TestMCPServer_HandleGetTestDetails_FuzzFailingInput 测试返回并加载模糊测试的失败输入
+func TestMCPServer_HandleGetTestDetails_FuzzFailingInput(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	moduleRoot := t.TempDir()
+	corpusDir := filepath.Join(moduleRoot, "testdata", "fuzz", "FuzzParse")
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		t.Fatalf("Failed to create corpus dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleRoot, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(corpusDir, "ff00"), []byte("go test fuzz v1\n[]byte(\"\\xff\")\n"), 0644); err != nil {
+		t.Fatalf("Failed to write corpus file: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `{"Action":"run","Package":"example","Test":"FuzzParse"}
+{"Action":"output","Package":"example","Test":"FuzzParse","Output":"    Failing input written to testdata/fuzz/FuzzParse/ff00\n"}
+{"Action":"fail","Package":"example","Test":"FuzzParse","Elapsed":1}`)
+
+	params := &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, TestName: "FuzzParse", ModuleRoot: moduleRoot},
+	}
+
+	// Act
+	result, err := server.handleGetTestDetails(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["kind"] != parser.KindFuzz {
+		t.Errorf("Expected fuzz kind, got %v", result.Meta["kind"])
+	}
+	fuzz, ok := result.Meta["fuzz"].(*parser.FuzzInfo)
+	if !ok || fuzz == nil {
+		t.Fatalf("Expected fuzz info, got %v", result.Meta["fuzz"])
+	}
+	if len(fuzz.FailingInputValues) != 1 || fuzz.FailingInputValues[0] != `[]byte("\xff")` {
+		t.Errorf("Expected corpus values to be loaded, got %v", fuzz.FailingInputValues)
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_Coverage 测试返回各包覆盖率和加权总覆盖率
+func TestMCPServer_HandleAnalyzeTestLog_Coverage(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `ok  	example.com/app/parse	0.031s	coverage: 40.0% of statements
+ok  	example.com/app/store	0.012s	coverage: 100.0% of statements`)
+	profileFile := createTempTestFile(t, `mode: set
+example.com/app/parse/parse.go:10.34,12.2 2 1
+example.com/app/parse/parse.go:14.2,16.3 3 0
+example.com/app/store/store.go:5.20,8.2 5 1
+`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile, CoverProfile: profileFile},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	coverage := result.Meta["coverage"].([]parser.PackageCoverage)
+	if len(coverage) != 2 || coverage[0].Percent != 40 {
+		t.Errorf("Unexpected package coverage: %+v", coverage)
+	}
+	overall, ok := result.Meta["overall_coverage"].(*float64)
+	if !ok || overall == nil || *overall != 70 {
+		t.Errorf("Expected weighted overall coverage 70%%, got %v", result.Meta["overall_coverage"])
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_Packages 测试返回各包的结果和失败的包
+func TestMCPServer_HandleAnalyzeTestLog_Packages(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `--- FAIL: TestStore (0.01s)
+FAIL
+FAIL	example.com/app/store	0.210s
+ok  	example.com/app/parse	(cached)
+?   	example.com/app/cmd	[no test files]`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	packages := result.Meta["packages"].([]parser.PackageResult)
+	if len(packages) != 3 || packages[1].Status != parser.PackagePass || !packages[1].Cached {
+		t.Errorf("Unexpected packages: %+v", packages)
+	}
+	failed := result.Meta["failed_packages"].([]string)
+	if len(failed) != 1 || failed[0] != "example.com/app/store" {
+		t.Errorf("Expected store to be the only failed package, got %v", failed)
+	}
+	if !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "3 个包中 1 个失败") {
+		t.Errorf("Expected package summary in text, got %q", result.Content[0].(*mcp.TextContent).Text)
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_PackageFailure 测试没有失败测试的包失败会让运行不通过
+func TestMCPServer_HandleAnalyzeTestLog_PackageFailure(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `=== RUN   TestA
+--- PASS: TestA (0.00s)
+PASS
+exit status 1
+FAIL	example.com/app	0.010s`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["all_tests_passed"] != false {
+		t.Errorf("Expected all_tests_passed to be false")
+	}
+	failures := result.Meta["package_failures"].([]parser.PackageResult)
+	if len(failures) != 1 || failures[0].FailureReason != "exit status 1" {
+		t.Errorf("Unexpected package failures: %+v", failures)
+	}
+	if !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "exit status 1") {
+		t.Errorf("Expected failure reason in text, got %q", result.Content[0].(*mcp.TextContent).Text)
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_Attributes 测试按属性过滤和分组统计
+func TestMCPServer_HandleAnalyzeTestLog_Attributes(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `{"Action":"run","Package":"example","Test":"TestCharge"}
+{"Action":"attr","Package":"example","Test":"TestCharge","Key":"owner","Value":"payments"}
+{"Action":"fail","Package":"example","Test":"TestCharge","Elapsed":0}
+{"Action":"run","Package":"example","Test":"TestRefund"}
+{"Action":"attr","Package":"example","Test":"TestRefund","Key":"owner","Value":"payments"}
+{"Action":"pass","Package":"example","Test":"TestRefund","Elapsed":0}
+{"Action":"run","Package":"example","Test":"TestLogin"}
+{"Action":"attr","Package":"example","Test":"TestLogin","Key":"owner","Value":"auth"}
+{"Action":"pass","Package":"example","Test":"TestLogin","Elapsed":0}`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile, Attributes: []string{"owner=auth"}, GroupBy: "owner"},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["total_tests"] != 1 || result.Meta["all_tests_passed"] != true {
+		t.Errorf("Expected only the auth test to be counted, got total=%v passed=%v", result.Meta["total_tests"], result.Meta["all_tests_passed"])
+	}
+	groups := result.Meta["attribute_groups"].([]parser.AttributeGroup)
+	if len(groups) != 1 || groups[0].Value != "auth" {
+		t.Errorf("Unexpected groups: %+v", groups)
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_AttributesWithPackageFailure 测试按属性过滤时包失败仍让运行不通过
+func TestMCPServer_HandleAnalyzeTestLog_AttributesWithPackageFailure(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `=== RUN   TestLogin
+=== ATTR  TestLogin owner auth
+--- PASS: TestLogin (0.00s)
+PASS
+exit status 1
+FAIL	example.com/app	0.010s`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile, Attributes: []string{"owner=auth"}},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["total_tests"] != 1 || result.Meta["all_tests_passed"] != false {
+		t.Errorf("Expected package failure to fail the filtered run, got total=%v passed=%v", result.Meta["total_tests"], result.Meta["all_tests_passed"])
+	}
+}
+
+// TestMCPServer_HandleGetTestDetails_Attributes 测试不指定测试名时按属性列出并分组测试
+func TestMCPServer_HandleGetTestDetails_Attributes(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `=== RUN   TestCharge
+=== ATTR  TestCharge owner payments
+=== ATTR  TestCharge kind integration
+--- FAIL: TestCharge (0.10s)
+=== RUN   TestParse
+=== ATTR  TestParse owner payments
+=== ATTR  TestParse kind unit
+--- PASS: TestParse (0.00s)
+FAIL
+FAIL	example.com/app	0.120s`)
+
+	params := &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, Attributes: []string{"owner=payments"}, GroupBy: "kind"},
+	}
+
+	// Act
+	result, err := server.handleGetTestDetails(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tests := result.Meta["matching_tests"].([]TestSummary)
+	if len(tests) != 2 || tests[0].TestName != "example.com/app.TestCharge" || tests[0].Status != "fail" {
+		t.Errorf("Unexpected matching tests: %+v", tests)
+	}
+	groups := result.Meta["attribute_groups"].([]parser.AttributeGroup)
+	if len(groups) != 2 || groups[0].Value != "integration" || groups[0].Failed != 1 {
+		t.Errorf("Unexpected groups: %+v", groups)
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_GroupSkipped 测试按跳过原因分组
+func TestMCPServer_HandleAnalyzeTestLog_GroupSkipped(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `=== RUN   TestDocker
+    docker_test.go:15: requires docker
+--- SKIP: TestDocker (0.00s)
+=== RUN   TestRedis
+    redis_test.go:8: requires docker
+--- SKIP: TestRedis (0.00s)
+=== RUN   TestParse
+--- PASS: TestParse (0.00s)
+PASS
+ok  	example.com/app	0.010s`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile, GroupSkipped: true},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	groups := result.Meta["skip_reasons"].([]parser.SkipGroup)
+	if len(groups) != 1 || groups[0].Reason != "requires docker" || groups[0].Count != 2 {
+		t.Errorf("Unexpected skip groups: %+v", groups)
+	}
+	if result.Meta["skipped_tests_count"] != 2 {
+		t.Errorf("Expected 2 skipped tests, got %v", result.Meta["skipped_tests_count"])
+	}
+}
+
+// TestMCPServer_HandleAnalyzeTestLog_IncompleteRun 测试被中断的运行不算通过并返回终止原因
+func TestMCPServer_HandleAnalyzeTestLog_IncompleteRun(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `=== RUN   TestParse
+--- PASS: TestParse (0.00s)
+=== RUN   TestUpload
+signal: killed
+FAIL	example.com/app	30.012s`)
+
+	params := &mcp.CallToolParamsFor[AnalyzeTestLogRequest]{
+		Arguments: AnalyzeTestLogRequest{FilePath: tempFile},
+	}
+
+	// Act
+	result, err := server.handleAnalyzeTestLog(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta["all_tests_passed"] != false || result.Meta["incomplete"] != true {
+		t.Errorf("Expected incomplete run not to pass, got all_tests_passed=%v incomplete=%v", result.Meta["all_tests_passed"], result.Meta["incomplete"])
+	}
+	names := result.Meta["incomplete_test_names"].([]string)
+	if len(names) != 1 || names[0] != "example.com/app.TestUpload" {
+		t.Errorf("Unexpected incomplete tests: %v", names)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "运行被中断（signal: killed），1 个测试未完成") {
+		t.Errorf("Expected termination in text, got %q", text)
+	}
+}
+
+// TestMCPServer_HandleGetTestDetails_GoroutineAnalysis 测试超时测试的详情附带协程转储分析
+func TestMCPServer_HandleGetTestDetails_GoroutineAnalysis(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tempFile := createTempTestFile(t, `=== RUN   TestPool
+panic: test timed out after 10m0s
+	running tests:
+		TestPool (10m0s)
+
+goroutine 7 [semacquire, 10 minutes]:
+sync.(*WaitGroup).Wait(0xc00001c0b0)
+	/usr/local/go/src/sync/waitgroup.go:116 +0x48
+example.com/app/pool.TestPool(0xc000007a00)
+	/home/dev/app/pool/pool_test.go:25 +0x9a
+
+goroutine 8 [chan receive, 10 minutes]:
+example.com/app/pool.(*Pool).worker(0xc0000a4000)
+	/home/dev/app/pool/pool.go:40 +0x65
+
+goroutine 9 [chan receive, 10 minutes]:
+example.com/app/pool.(*Pool).worker(0xc0000a4000)
+	/home/dev/app/pool/pool.go:40 +0x65
+exit status 2
+FAIL	example.com/app/pool	600.012s`)
+
+	params := &mcp.CallToolParamsFor[GetTestDetailsRequest]{
+		Arguments: GetTestDetailsRequest{FilePath: tempFile, TestName: "TestPool"},
+	}
+
+	// Act
+	result, err := server.handleGetTestDetails(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	analysis := result.Meta["goroutines"].(*parser.GoroutineAnalysis)
+	if analysis == nil || analysis.Total != 3 || len(analysis.Groups) != 2 {
+		t.Fatalf("Unexpected goroutine analysis: %+v", analysis)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "最多的等待原因为 chan receive（2 个）") || !strings.Contains(text, "测试代码阻塞于 /home/dev/app/pool/pool_test.go:25（semacquire，1 个协程，已阻塞 10 分钟）") {
+		t.Errorf("Expected goroutine summary in text, got %q", text)
+	}
+}
+
+// TestMCPServer_HandleGetCoverage_Function 测试按函数返回覆盖率
+func TestMCPServer_HandleGetCoverage_Function(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	moduleRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(moduleRoot, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	source := "package app\n\nfunc Add(a, b int) int {\n\tif a < 0 {\n\t\treturn b\n\t}\n\treturn a + b\n}\n"
+	if err := os.WriteFile(filepath.Join(moduleRoot, "add.go"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write source file: %v", err)
+	}
+	profileFile := createTempTestFile(t, `mode: set
+example.com/app/add.go:3.24,4.11 1 1
+example.com/app/add.go:4.11,6.3 1 0
+example.com/app/add.go:7.2,7.14 1 1
+`)
+
+	params := &mcp.CallToolParamsFor[GetCoverageRequest]{
+		Arguments: GetCoverageRequest{CoverProfiles: []string{profileFile}, ModuleRoot: moduleRoot, Function: "Add"},
+	}
+
+	// Act
+	result, err := server.handleGetCoverage(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	functions := result.Meta["functions"].([]coverprofile.FuncCoverage)
+	if len(functions) != 1 || functions[0].Covered != 2 || functions[0].Total != 3 {
+		t.Fatalf("Unexpected function coverage: %+v", functions)
+	}
+	if len(functions[0].Uncovered) != 1 || functions[0].Uncovered[0].Start != 4 || functions[0].Uncovered[0].End != 6 {
+		t.Errorf("Unexpected uncovered ranges: %+v", functions[0].Uncovered)
+	}
+}
+
+// TestMCPServer_HandleGetCoverage_SourceErrors 测试部分源文件缺失时仍返回其他文件的函数覆盖率
+func TestMCPServer_HandleGetCoverage_SourceErrors(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	moduleRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(moduleRoot, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	source := "package app\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n"
+	if err := os.WriteFile(filepath.Join(moduleRoot, "add.go"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write source file: %v", err)
+	}
+	profileFile := createTempTestFile(t, `mode: set
+example.com/app/add.go:3.24,5.2 1 1
+example.com/app/removed.go:3.20,5.2 1 0
+`)
+
+	params := &mcp.CallToolParamsFor[GetCoverageRequest]{
+		Arguments: GetCoverageRequest{CoverProfiles: []string{profileFile}, ModuleRoot: moduleRoot},
+	}
+
+	// Act
+	result, err := server.handleGetCoverage(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	functions := result.Meta["functions"].([]coverprofile.FuncCoverage)
+	if len(functions) != 1 || functions[0].Name != "Add" {
+		t.Errorf("Expected Add coverage despite missing source file, got %+v", functions)
+	}
+	sourceErrors := result.Meta["source_errors"].([]coverprofile.SourceError)
+	if len(sourceErrors) != 1 || sourceErrors[0].File != "example.com/app/removed.go" {
+		t.Errorf("Unexpected source errors: %+v", sourceErrors)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "1 个文件的源码无法解析") {
+		t.Errorf("Expected source error count in text, got %q", text)
+	}
+}
+
+// TestMCPServer_HandleGetCoverage_FunctionWithoutModuleRoot 测试缺少模块根目录时按函数查询失败
+func TestMCPServer_HandleGetCoverage_FunctionWithoutModuleRoot(t *testing.T) {
+	// Arrange
+	server, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	params := &mcp.CallToolParamsFor[GetCoverageRequest]{
+		Arguments: GetCoverageRequest{CoverProfiles: []string{"cover.out"}, Function: "Add"},
+	}
+
+	// Act
+	_, err = server.handleGetCoverage(context.Background(), &mcp.ServerSession{}, params)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error when module_root is missing")
+	}
+}
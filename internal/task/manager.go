@@ -183,6 +183,10 @@ func (t *Task) GetStatus() map[string]interface{} {
 			"passed_tests":  t.Result.PassedTests,
 			"failed_tests":  t.Result.FailedTests,
 			"skipped_tests": t.Result.SkippedTests,
+			"timed_out":     t.Result.TimedOut,
+			"timed_out_tests": t.Result.TimedOutTests,
+			"incomplete_tests": t.Result.IncompleteTests,
+			"package_failures": t.Result.PackageFailures,
 			"failed_test_names": t.Result.FailedTestNames,
 			"passed_test_names": t.Result.PassedTestNames,
 		}
@@ -200,9 +204,10 @@ func (t *Task) GetTestDetails(testName string) map[string]interface{} {
 		return nil
 	}
 	
-	if details, exists := t.Result.TestDetails[testName]; exists {
+	if details, err := t.Result.LookupTest("", testName); err == nil {
 		return map[string]interface{}{
 			"test_name": testName,
+			"package":   details.Package,
 			"status":    details.Status,
 			"output":    details.Output,
 			"error":     details.Error,